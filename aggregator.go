@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Group is a group of reported errors sharing a fingerprint, counted over
+// the window of the Aggregator. The window of a group starts with its first
+// report and rolls when a report arrives after it ended, resetting Count and
+// FirstSeen.
+type Group struct {
+	Fingerprint string    `json:"fingerprint"`
+	StatusCode  Code      `json:"status_code"`
+	Count       int       `json:"count"`
+	FirstSeen   time.Time `json:"first_seen"` // first report of the window
+	LastSeen    time.Time `json:"last_seen"`
+	Sample      *Error    `json:"sample"` // last reported error of the group
+}
+
+// Aggregator is a Sink grouping the reported errors by fingerprint, for in
+// process triage. Groups not seen within the window are dropped. It is an
+// http.Handler serving the groups as json, e.g. on a debug endpoint.
+//
+//	agg := errors.NewAggregator(15 * time.Minute)
+//	errors.AddSink(agg)
+//	debugMux.Handle("/debug/errors", agg)
+type Aggregator struct {
+	window time.Duration
+
+	mu     sync.Mutex
+	groups map[string]*Group
+}
+
+// NewAggregator returns an Aggregator keeping the groups seen within the
+// given window.
+func NewAggregator(window time.Duration) *Aggregator {
+	return &Aggregator{
+		window: window,
+		groups: map[string]*Group{},
+	}
+}
+
+// Report implements Sink.
+func (a *Aggregator) Report(e *Error) {
+	if e.pooled {
+		e = e.copy()
+	}
+	fingerprint := e.Fingerprint()
+	t := now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	g, ok := a.groups[fingerprint]
+	if !ok {
+		if len(a.groups) >= maxFingerprints {
+			// evict only when full, so reports stay O(1)
+			if a.evict(t); len(a.groups) >= maxFingerprints {
+				return
+			}
+		}
+		g = &Group{Fingerprint: fingerprint, StatusCode: e.StatusCode, FirstSeen: t}
+		a.groups[fingerprint] = g
+	}
+	if t.Sub(g.FirstSeen) > a.window {
+		g.Count, g.FirstSeen = 0, t
+	}
+	g.Count++
+	g.LastSeen = t
+	g.Sample = e
+}
+
+// Flush implements Sink.
+func (a *Aggregator) Flush() {}
+
+// Groups returns the groups seen within the window, the most reported
+// first.
+func (a *Aggregator) Groups() []Group {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.evict(now())
+
+	groups := make([]Group, 0, len(a.groups))
+	for _, g := range a.groups {
+		groups = append(groups, *g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Fingerprint < groups[j].Fingerprint
+	})
+	return groups
+}
+
+// ServeHTTP serves the Groups as a json array.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	json.NewEncoder(w).Encode(a.Groups())
+}
+
+// evict drops the groups not seen within the window.
+func (a *Aggregator) evict(t time.Time) {
+	for fingerprint, g := range a.groups {
+		if t.Sub(g.LastSeen) > a.window {
+			delete(a.groups, fingerprint)
+		}
+	}
+}
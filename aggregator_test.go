@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAggregator(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	current := start
+	now = func() time.Time { return current }
+	defer func() {
+		now = time.Now
+	}()
+
+	agg := NewAggregator(10 * time.Minute)
+
+	agg.Report(NotFound("no account"))
+	current = start.Add(5 * time.Minute)
+	agg.Report(InternalServer("db down"))
+	agg.Report(InternalServer("db down", SetMeta(Meta{"db": "main"})))
+
+	groups := agg.Groups()
+	if len(groups) != 2 {
+		t.Fatalf("Groups() length\n exp: 2\n got: %d\n", len(groups))
+	}
+
+	g := groups[0]
+	if g.StatusCode != StatusInternalServerError || g.Count != 2 || g.FirstSeen != current || g.Sample.Meta["db"] != "main" {
+		t.Errorf("Groups()[0]\n exp: internal_server x2 with last sample\n got: %+v\n", g)
+	}
+	if groups[1].Count != 1 || groups[1].FirstSeen != start {
+		t.Errorf("Groups()[1]\n exp: not_found x1\n got: %+v\n", groups[1])
+	}
+
+	current = start.Add(12 * time.Minute)
+	if groups := agg.Groups(); len(groups) != 1 || groups[0].StatusCode != StatusInternalServerError {
+		t.Errorf("Groups() after window\n exp: internal_server group\n got: %+v\n", groups)
+	}
+
+	current = start.Add(16 * time.Minute)
+	agg.Report(InternalServer("db down"))
+	if groups := agg.Groups(); len(groups) != 1 || groups[0].Count != 1 || groups[0].FirstSeen != current {
+		t.Errorf("Groups() after the window rolled\n exp: internal_server x1 first seen at %v\n got: %+v\n", current, groups)
+	}
+
+	rec := httptest.NewRecorder()
+	agg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/errors", nil))
+
+	var served []Group
+	if err := json.NewDecoder(rec.Body).Decode(&served); err != nil || len(served) != 1 || served[0].Sample.Message != "db down" {
+		t.Errorf("ServeHTTP()\n exp: internal_server group\n got: %+v (%v)\n", served, err)
+	}
+}
+
+func TestAggregatorEviction(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	current := start
+	now = func() time.Time { return current }
+	defer func() {
+		now = time.Now
+	}()
+
+	agg := NewAggregator(time.Minute)
+	for i := 0; i < maxFingerprints; i++ {
+		agg.Report(InternalServer(fmt.Sprintf("error %d", i)))
+	}
+
+	agg.Report(NotFound("full"))
+	if got := len(agg.groups); got != maxFingerprints {
+		t.Errorf("Report() past maxFingerprints within the window\n exp: %d groups\n got: %d\n", maxFingerprints, got)
+	}
+
+	current = start.Add(2 * time.Minute)
+	agg.Report(NotFound("evicted"))
+	if groups := agg.Groups(); len(groups) != 1 || groups[0].Sample.Message != "evicted" {
+		t.Errorf("Report() past maxFingerprints after the window\n exp: the new group\n got: %d groups\n", len(groups))
+	}
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TPPCodeKey is the Meta key holding the Berlin Group message code of the
+// error, e.g. "CONSENT_EXPIRED", overriding the one derived from its code.
+const TPPCodeKey = "tpp_code"
+
+// maxTPPText is the maximum length of the text of a TPPMessage.
+const maxTPPText = 500
+
+// TPPMessage is an error message of the Berlin Group NextGenPSD2 API.
+type TPPMessage struct {
+	Category string `json:"category"` // always "ERROR"
+	Code     string `json:"code"`     // e.g. "FORMAT_ERROR"
+	Path     string `json:"path,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// tppCodes are the Berlin Group message codes of the errors, by code.
+var tppCodes = map[Code]string{
+	StatusBadRequest:          "FORMAT_ERROR",
+	StatusUnauthorized:        "TOKEN_INVALID",
+	StatusPaymentRequired:     "FUNDS_NOT_AVAILABLE",
+	StatusForbidden:           "SERVICE_BLOCKED",
+	StatusNotFound:            "RESOURCE_UNKNOWN",
+	StatusMethodNotAllowed:    "SERVICE_INVALID",
+	StatusNotAcceptable:       "REQUESTED_FORMATS_INVALID",
+	StatusUnprocessableEntity: "FORMAT_ERROR",
+	StatusTooManyRequests:     "ACCESS_EXCEEDED",
+}
+
+// TPPMessages returns the error as Berlin Group tppMessages: one message per
+// field error of composite errors, with the field as path, or a single one
+// otherwise. The code is taken from the Meta under TPPCodeKey or derived from
+// the error code, e.g. FORMAT_ERROR for bad_request.
+func (e *Error) TPPMessages() []TPPMessage {
+	var msgs []TPPMessage
+	for _, sub := range e.Errors() {
+		if field, ok := sub.Meta[FieldKey]; ok {
+			msg := sub.tppMessage()
+			msg.Path = fmt.Sprint(field)
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(msgs) == 0 {
+		msgs = append(msgs, e.tppMessage())
+	}
+	return msgs
+}
+
+// tppMessage returns the TPPMessage of the error.
+func (e *Error) tppMessage() TPPMessage {
+	msg := TPPMessage{Category: "ERROR", Code: "INTERNAL_SERVER_ERROR", Text: e.UserMessage}
+	if code, ok := tppCodes[e.StatusCode]; ok {
+		msg.Code = code
+	}
+	if code, ok := e.Meta[TPPCodeKey].(string); ok && len(code) > 0 {
+		msg.Code = code
+	}
+
+	if len(msg.Text) == 0 {
+		msg.Text = e.Message
+	}
+	if runes := []rune(msg.Text); len(runes) > maxTPPText {
+		msg.Text = string(runes[:maxTPPText])
+	}
+	return msg
+}
+
+// MarshalBerlinGroup returns the Berlin Group error response of the error,
+// e.g. {"tppMessages":[{"category":"ERROR","code":"FORMAT_ERROR","text":"invalid iban"}]}.
+// The registered scrubbers are applied first.
+func (e *Error) MarshalBerlinGroup() ([]byte, error) {
+	return json.Marshal(struct {
+		TPPMessages []TPPMessage `json:"tppMessages"`
+	}{scrub(e).TPPMessages()})
+}
+
+// WriteBerlinGroup is like WriteHTTP but writes the Berlin Group error
+// response, for the NextGenPSD2 endpoints.
+func WriteBerlinGroup(w http.ResponseWriter, err error) {
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+	logError(e)
+	e = e.sanitized()
+
+	b, _ := e.MarshalBerlinGroup() // there is no much more to do in case of failure
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(e.StatusCode.httpStatus())
+	w.Write(b)
+}
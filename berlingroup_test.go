@@ -0,0 +1,54 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMarshalBerlinGroup(t *testing.T) {
+	tests := []struct {
+		err *Error
+		exp string
+	}{
+		{
+			BadRequest("invalid iban"),
+			`{"tppMessages":[{"category":"ERROR","code":"FORMAT_ERROR","text":"invalid iban"}]}`,
+		},
+		{
+			Unauthorized("consent expired", SetMeta(Meta{TPPCodeKey: "CONSENT_EXPIRED"}), func(e *Error) { e.UserMessage = "El consentimiento expiró" }),
+			`{"tppMessages":[{"category":"ERROR","code":"CONSENT_EXPIRED","text":"El consentimiento expiró"}]}`,
+		},
+		{
+			InvalidParamsFromError(stderrors.Join(FieldError("debtorAccount.iban", "required"), FieldError("instructedAmount", "invalid format")), "invalid params"),
+			`{"tppMessages":[{"category":"ERROR","code":"FORMAT_ERROR","path":"debtorAccount.iban","text":"required"},` +
+				`{"category":"ERROR","code":"FORMAT_ERROR","path":"instructedAmount","text":"invalid format"}]}`,
+		},
+		{
+			InternalServer(strings.Repeat("x", 600)),
+			`{"tppMessages":[{"category":"ERROR","code":"INTERNAL_SERVER_ERROR","text":"` + strings.Repeat("x", maxTPPText) + `"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.err.MarshalBerlinGroup()
+		if err != nil {
+			t.Fatalf("MarshalBerlinGroup() unexpected error: %v", err)
+		}
+		if string(got) != tt.exp {
+			t.Errorf("(%v).MarshalBerlinGroup()\n exp: %s\n got: %s\n", tt.err, tt.exp, got)
+		}
+	}
+}
+
+func TestWriteBerlinGroup(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteBerlinGroup(rec, NotFound("no consent"))
+
+	exp := `{"tppMessages":[{"category":"ERROR","code":"RESOURCE_UNKNOWN","text":"no consent"}]}`
+	if got := rec.Body.String(); rec.Code != http.StatusNotFound || got != exp {
+		t.Errorf("WriteBerlinGroup()\n exp: %d %s\n got: %d %s\n", http.StatusNotFound, exp, rec.Code, got)
+	}
+}
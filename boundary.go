@@ -0,0 +1,82 @@
+package errors
+
+import "net/http"
+
+// Option configures the interceptors and writers of errors crossing a
+// service boundary.
+type Option func(*options)
+
+type options struct {
+	allowed map[Code]bool
+}
+
+// AllowCodes restricts the codes that may cross a trust boundary, e.g. to a
+// public API. Errors with other codes are downgraded to internal_server
+// errors, keeping the original as InternalError and its request id and
+// serving data in Meta, so the internal taxonomy is not leaked. Errors of
+// the internal_server class (5xx) always cross it unchanged.
+func AllowCodes(codes ...Code) Option {
+	return func(o *options) {
+		if o.allowed == nil {
+			o.allowed = map[Code]bool{}
+		}
+		for _, c := range codes {
+			o.allowed[c] = true
+		}
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// correlationKeys are the Meta keys kept by the errors downgraded by
+// AllowCodes.
+var correlationKeys = []string{RequestIDKey, ServingDataKey}
+
+// boundary returns e, or its internal_server downgrade if its code is not
+// allowed to cross the boundary.
+func (o options) boundary(e *Error) *Error {
+	if e == nil || o.allowed == nil || o.allowed[e.StatusCode] || e.StatusCode.httpStatus() >= 500 {
+		return e
+	}
+
+	var meta Meta
+	for _, key := range correlationKeys {
+		if value, ok := e.Meta[key]; ok {
+			if meta == nil {
+				meta = Meta{}
+			}
+			meta[key] = value
+		}
+	}
+	return InternalServerFromError(e, UnexpectedMsg, SetMetaNoCopy(meta))
+}
+
+// Writer writes errors as http responses like WriteHTTP and
+// WriteHTTPRequest, with the given options.
+//
+//	public := errors.NewWriter(errors.AllowCodes(errors.StatusBadRequest, errors.StatusNotFound))
+//	public.WriteHTTPRequest(w, r, err)
+type Writer struct {
+	opts options
+}
+
+// NewWriter returns a Writer with the given options.
+func NewWriter(opts ...Option) *Writer {
+	return &Writer{opts: newOptions(opts)}
+}
+
+// WriteHTTP is like the package WriteHTTP.
+func (wr *Writer) WriteHTTP(w http.ResponseWriter, err error) {
+	WriteHTTP(w, wr.opts.boundary(BuildError(err)))
+}
+
+// WriteHTTPRequest is like the package WriteHTTPRequest.
+func (wr *Writer) WriteHTTPRequest(w http.ResponseWriter, r *http.Request, err error) {
+	WriteHTTPRequest(w, r, wr.opts.boundary(BuildError(err)))
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAllowCodes(t *testing.T) {
+	opts := []Option{AllowCodes(StatusBadRequest, StatusNotFound)}
+	interceptor := UnaryServerInterceptor(opts...)
+	writer := NewWriter(opts...)
+
+	tests := []struct {
+		err error
+		exp Code
+	}{
+		{NotFound("no account"), StatusNotFound},
+		{Delinquent("insufficient funds"), StatusInternalServerError},
+		{InternalServer("db down"), StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, tt.err
+		})
+		got := FromGRPC(err)
+		if got.StatusCode != tt.exp {
+			t.Errorf("UnaryServerInterceptor(AllowCodes) code of %v\n exp: %d\n got: %d\n", tt.err, tt.exp, got.StatusCode)
+		}
+		if tt.exp != CodeOf(tt.err) && got.Message != UnexpectedMsg {
+			t.Errorf("UnaryServerInterceptor(AllowCodes) msg of %v\n exp: %q\n got: %q\n", tt.err, UnexpectedMsg, got.Message)
+		}
+
+		rec := httptest.NewRecorder()
+		writer.WriteHTTPRequest(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+		if rec.Code != int(tt.exp) {
+			t.Errorf("Writer.WriteHTTPRequest(AllowCodes) status of %v\n exp: %d\n got: %d\n", tt.err, tt.exp, rec.Code)
+		}
+	}
+
+	// statuses of downstream calls
+	statuses := []struct {
+		err error
+		exp Code
+	}{
+		{status.Error(codes.Code(StatusForbidden), `{"msg":"nope"}`), StatusInternalServerError},
+		{status.Error(codes.Code(StatusNotFound), `{"msg":"no account"}`), StatusNotFound},
+	}
+
+	for _, tt := range statuses {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, tt.err
+		})
+		if got := FromGRPC(err); got.StatusCode != tt.exp {
+			t.Errorf("UnaryServerInterceptor(AllowCodes) code of %v\n exp: %d\n got: %d\n", tt.err, tt.exp, got.StatusCode)
+		}
+	}
+
+	o := options{allowed: map[Code]bool{StatusNotFound: true}}
+	downgraded := o.boundary(Delinquent("insufficient funds", SetMeta(Meta{RequestIDKey: "r1", "account": "a1"})))
+	if !errors.Is(downgraded, ErrDelinquent) {
+		t.Errorf("boundary() internal error\n exp: delinquent\n got: %v\n", downgraded.InternalError)
+	}
+	if exp := (Meta{RequestIDKey: "r1"}); !reflect.DeepEqual(downgraded.Meta, exp) {
+		t.Errorf("boundary() meta\n exp: %v\n got: %v\n", exp, downgraded.Meta)
+	}
+
+	internal := InternalServer("boom", SetMeta(Meta{RequestIDKey: "r1"}))
+	if got := o.boundary(internal); got != internal {
+		t.Errorf("boundary() of an internal_server error\n exp: %v\n got: %v\n", internal, got)
+	}
+}
@@ -0,0 +1,51 @@
+package errors
+
+import "google.golang.org/grpc/codes"
+
+// canonical maps status codes to their closest canonical gRPC code.
+var canonical = map[Code]codes.Code{
+	StatusBadRequest:          codes.InvalidArgument,
+	StatusUnauthorized:        codes.Unauthenticated,
+	StatusPaymentRequired:     codes.FailedPrecondition,
+	StatusForbidden:           codes.PermissionDenied,
+	StatusNotFound:            codes.NotFound,
+	StatusMethodNotAllowed:    codes.Unimplemented,
+	StatusNotAcceptable:       codes.InvalidArgument,
+	StatusRequestTimeout:      codes.DeadlineExceeded,
+	StatusUnprocessableEntity: codes.InvalidArgument,
+	StatusTooManyRequests:     codes.ResourceExhausted,
+	StatusClientClosedRequest: codes.Canceled,
+	StatusInternalServerError: codes.Internal,
+}
+
+// fromCanonical maps canonical gRPC codes back to status codes.
+var fromCanonical = map[codes.Code]Code{
+	codes.InvalidArgument:    StatusBadRequest,
+	codes.Unauthenticated:    StatusUnauthorized,
+	codes.FailedPrecondition: StatusPaymentRequired,
+	codes.PermissionDenied:   StatusForbidden,
+	codes.NotFound:           StatusNotFound,
+	codes.Unimplemented:      StatusMethodNotAllowed,
+	codes.ResourceExhausted:  StatusTooManyRequests,
+	codes.DeadlineExceeded:   StatusRequestTimeout,
+	codes.Canceled:           StatusClientClosedRequest,
+	codes.Internal:           StatusInternalServerError,
+}
+
+// Canonical returns the canonical gRPC code closest to the status code,
+// codes.Unknown if there is none.
+func (c Code) Canonical() codes.Code {
+	if code, ok := canonical[c]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// CodeFromCanonical returns the status code closest to the given canonical
+// gRPC code, StatusInternalServerError if there is none.
+func CodeFromCanonical(c codes.Code) Code {
+	if code, ok := fromCanonical[c]; ok {
+		return code
+	}
+	return StatusInternalServerError
+}
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"encoding/json"
+)
+
+// MarshalCanonicalJSON is like MarshalJSON but the output is deterministic,
+// so it can be hashed, signed or compared with golden files: fields are
+// always in the same order and meta keys, including those of nested maps
+// and errors, are sorted ignoring the SetOrderedMeta order.
+func (e *Error) MarshalCanonicalJSON() ([]byte, error) {
+	e = scrub(e)
+	e = e.named(e.cfg().Naming)
+
+	var meta json.RawMessage
+	if len(e.Meta) > 0 {
+		var err error
+		if meta, err = canonicalMeta(e.Meta).MarshalJSON(); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(e.jsonObject(meta, true))
+}
+
+// canonicalError is an Error nested in the output of MarshalCanonicalJSON.
+type canonicalError Error
+
+// MarshalJSON implements json.Marshaler.
+func (c *canonicalError) MarshalJSON() ([]byte, error) {
+	return (*Error)(c).MarshalCanonicalJSON()
+}
+
+// canonicalMeta returns a copy of m whose errors, including those nested in
+// maps and slices, are serialized with MarshalCanonicalJSON.
+func canonicalMeta(m Meta) Meta {
+	if m == nil {
+		return nil
+	}
+
+	c := make(Meta, len(m))
+	for key, value := range m {
+		c[key] = canonicalValue(value)
+	}
+	return c
+}
+
+// canonicalValue returns v with its errors serialized with
+// MarshalCanonicalJSON, see canonicalMeta.
+func canonicalValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *Error:
+		if v != nil {
+			return (*canonicalError)(v)
+		}
+	case Meta:
+		return canonicalMeta(v)
+	case map[string]interface{}:
+		return canonicalMeta(v)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			values[i] = canonicalValue(value)
+		}
+		return values
+	}
+	return v
+}
+
+// marshalers returns the errors to serialize, with MarshalCanonicalJSON if
+// canonical is set.
+func marshalers(errs []*Error, canonical bool) []json.Marshaler {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	ms := make([]json.Marshaler, len(errs))
+	for i, e := range errs {
+		if canonical {
+			ms[i] = (*canonicalError)(e)
+		} else {
+			ms[i] = e
+		}
+	}
+	return ms
+}
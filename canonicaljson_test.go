@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"testing"
+)
+
+func TestMarshalCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		err *Error
+		exp string
+	}{
+		{NotFound("no account"), `{"msg":"no account","error_id":"not_found","status_code":404}`},
+		{
+			NotFound("no account", SetOrderedMeta("z", 1, "a", 2), SetMeta(Meta{"m": Meta{"y": "<b>", "x": nil}})),
+			`{"meta":{"a":2,"m":{"x":null,"y":"\u003cb\u003e"},"z":1},"msg":"no account","error_id":"not_found","status_code":404}`,
+		},
+		{
+			BadRequestFromError(joinErrors([]*Error{NotFound("no account", SetOrderedMeta("z", 1, "a", 2))}), "invalid",
+				SetMeta(Meta{"cause": Forbidden("denied", SetOrderedMeta("z", 1, "a", 2)), "list": []interface{}{Meta{"y": 1, "x": 2}}}),
+				AddSuppressed(InternalServer("rollback failed", SetOrderedMeta("z", 1, "a", 2)))),
+			`{"meta":{"cause":{"meta":{"a":2,"z":1},"msg":"denied","error_id":"forbidden","status_code":403},"list":[{"x":2,"y":1}]},` +
+				`"msg":"invalid","error_id":"bad_request","status_code":400,` +
+				`"errors":[{"meta":{"a":2,"z":1},"msg":"no account","error_id":"not_found","status_code":404}],` +
+				`"suppressed":[{"meta":{"a":2,"z":1},"msg":"rollback failed","error_id":"internal_server","status_code":500}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 3; i++ {
+			got, err := tt.err.MarshalCanonicalJSON()
+			if err != nil {
+				t.Fatalf("MarshalCanonicalJSON() unexpected error: %v", err)
+			}
+			if string(got) != tt.exp {
+				t.Errorf("MarshalCanonicalJSON()\n exp: %s\n got: %s\n", tt.exp, got)
+			}
+		}
+	}
+}
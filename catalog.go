@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Catalog returns the information of every registered code, sorted by code.
+func Catalog() []CodeInfo {
+	registry.RLock()
+	catalog := make([]CodeInfo, 0, len(registry.codes))
+	for _, info := range registry.codes {
+		if len(info.ID) == 0 {
+			info.ID = info.Code.String()
+		}
+		catalog = append(catalog, info)
+	}
+	registry.RUnlock()
+
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+	return catalog
+}
+
+// VerifyCatalog checks the Catalog against a snapshot, e.g. a committed
+// errorscatalog json output, and returns an error listing the breaking
+// changes: codes removed, codes whose id or http status changed and ids
+// moved to another code. New codes and changes of messages are allowed.
+func VerifyCatalog(snapshot []CodeInfo) error {
+	byCode := map[Code]CodeInfo{}
+	byID := map[string]Code{}
+	for _, info := range Catalog() {
+		byCode[info.Code] = info
+		byID[info.ID] = info.Code
+	}
+
+	var changes []string
+	for _, prev := range snapshot {
+		info, ok := byCode[prev.Code]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("code %d (%s) removed", prev.Code, prev.ID))
+		case info.ID != prev.ID:
+			changes = append(changes, fmt.Sprintf("code %d id changed from %s to %s", prev.Code, prev.ID, info.ID))
+		case info.HTTPStatus != prev.HTTPStatus:
+			changes = append(changes, fmt.Sprintf("code %d (%s) http status changed from %d to %d", prev.Code, prev.ID, prev.HTTPStatus, info.HTTPStatus))
+		}
+		if code, ok := byID[prev.ID]; ok && code != prev.Code {
+			changes = append(changes, fmt.Sprintf("id %s moved from code %d to %d", prev.ID, prev.Code, code))
+		}
+	}
+
+	if len(changes) > 0 {
+		return fmt.Errorf("errors: catalog changed: %s", strings.Join(changes, "; "))
+	}
+	return nil
+}
+
+// CatalogHandler returns an http.Handler that serves the Catalog as a json
+// array.
+func CatalogHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		json.NewEncoder(w).Encode(Catalog())
+	})
+}
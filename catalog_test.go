@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	const insufficientFunds Code = 1001
+
+	RegisterCode(CodeInfo{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: http.StatusPaymentRequired, Message: "insufficient funds"})
+	defer func() {
+		delete(registry.codes, insufficientFunds)
+		ids.Delete(insufficientFunds)
+	}()
+
+	catalog := Catalog()
+	for i := 1; i < len(catalog); i++ {
+		if catalog[i-1].Code >= catalog[i].Code {
+			t.Errorf("Catalog() not sorted by code: %d before %d", catalog[i-1].Code, catalog[i].Code)
+		}
+	}
+
+	byCode := map[Code]CodeInfo{}
+	for _, info := range catalog {
+		byCode[info.Code] = info
+	}
+
+	tests := []CodeInfo{
+		{Code: StatusBadRequest, ID: "bad_request", HTTPStatus: 400, Message: "bad request", Priority: PriorityIgnore},
+		{Code: StatusInternalServerError, ID: "internal_server", HTTPStatus: 500, Message: "internal server error", Retryable: true, Priority: PriorityPage},
+		{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: 402, Message: "insufficient funds", Priority: PriorityIgnore},
+	}
+
+	for _, exp := range tests {
+		if got := byCode[exp.Code]; !reflect.DeepEqual(got, exp) {
+			t.Errorf("Catalog()[%d]\n exp: %+v\n got: %+v\n", exp.Code, exp, got)
+		}
+	}
+
+	snapshot := []CodeInfo{
+		{Code: StatusBadRequest, ID: "bad_request", HTTPStatus: 400},
+		{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: 402, Message: "old message"},
+	}
+	if err := VerifyCatalog(snapshot); err != nil {
+		t.Errorf("VerifyCatalog() unexpected error: %v", err)
+	}
+
+	verifyTests := []struct {
+		info CodeInfo
+		exp  string
+	}{
+		{CodeInfo{Code: 1002, ID: "card_declined", HTTPStatus: 402}, "errors: catalog changed: code 1002 (card_declined) removed"},
+		{CodeInfo{Code: insufficientFunds, ID: "no_funds", HTTPStatus: 402}, "errors: catalog changed: code 1001 id changed from no_funds to insufficient_funds"},
+		{CodeInfo{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: 400}, "errors: catalog changed: code 1001 (insufficient_funds) http status changed from 400 to 402"},
+		{CodeInfo{Code: 1002, ID: "insufficient_funds", HTTPStatus: 402}, "errors: catalog changed: code 1002 (insufficient_funds) removed; id insufficient_funds moved from code 1002 to 1001"},
+	}
+
+	for _, tt := range verifyTests {
+		err := VerifyCatalog([]CodeInfo{tt.info})
+		if err == nil || err.Error() != tt.exp {
+			t.Errorf("VerifyCatalog(%+v)\n exp: %s\n got: %v\n", tt.info, tt.exp, err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	CatalogHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/errors", nil))
+
+	var served []CodeInfo
+	if err := json.NewDecoder(rec.Body).Decode(&served); err != nil || !reflect.DeepEqual(served, catalog) {
+		t.Errorf("CatalogHandler()\n exp: %+v\n got: %+v (%v)\n", catalog, served, err)
+	}
+}
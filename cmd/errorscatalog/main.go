@@ -0,0 +1,186 @@
+// Command errorscatalog renders the catalog of registered error codes into
+// machine-readable artifacts, so frontend and analytics consumers stay in
+// sync with the backend codes.
+//
+// Usage:
+//
+//	errorscatalog [-defs codes.json] [-format json|csv|ts] [-o file]
+//	errorscatalog [-defs codes.json] -verify catalog.json
+//
+// The catalog holds the built-in codes plus the custom codes defined in the
+// -defs file, a json array of errors.CodeInfo objects.
+//
+// With -verify the catalog is checked against a snapshot previously rendered
+// in json, failing if a code was removed or renumbered or its id or http
+// status changed, see errors.VerifyCatalog.
+//
+// Invalid flags or files and breaking changes exit with status 64, while I/O
+// failures exit with status 70.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Finciero/errors"
+)
+
+func main() {
+	defs := flag.String("defs", "", "json file with the custom code definitions")
+	format := flag.String("format", "json", "output format: json, csv or ts")
+	out := flag.String("o", "", "output file, stdout if empty")
+	snapshot := flag.String("verify", "", "json catalog to verify the codes against")
+	flag.Parse()
+
+	var err error
+	if len(*snapshot) > 0 {
+		err = verify(*defs, *snapshot)
+	} else {
+		err = run(*defs, *format, *out)
+	}
+	if err != nil {
+		errors.Fatal(err)
+	}
+}
+
+func run(defs, format, out string) error {
+	if len(defs) > 0 {
+		if err := register(defs); err != nil {
+			return err
+		}
+	}
+
+	if len(out) == 0 {
+		return render(os.Stdout, format, errors.Catalog())
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return errors.InternalServerFromError(err, "creating the output failed")
+	}
+	if err := render(f, format, errors.Catalog()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.InternalServerFromError(err, "closing the output failed")
+	}
+	return nil
+}
+
+func verify(defs, snapshot string) error {
+	if len(defs) > 0 {
+		if err := register(defs); err != nil {
+			return err
+		}
+	}
+
+	b, err := os.ReadFile(snapshot)
+	if err != nil {
+		return errors.InternalServerFromError(err, "reading the snapshot failed")
+	}
+
+	var catalog []errors.CodeInfo
+	if err := json.Unmarshal(b, &catalog); err != nil {
+		return errors.BadRequestFromError(fmt.Errorf("%s: %v", snapshot, err), "invalid snapshot")
+	}
+	if err := errors.VerifyCatalog(catalog); err != nil {
+		return errors.NewFromError(errors.StatusUnprocessableEntity, err, "breaking catalog changes")
+	}
+	return nil
+}
+
+// register registers the codes defined in the given json file.
+func register(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return errors.InternalServerFromError(err, "reading the definitions failed")
+	}
+
+	var infos []errors.CodeInfo
+	if err := json.Unmarshal(b, &infos); err != nil {
+		return errors.BadRequestFromError(fmt.Errorf("%s: %v", path, err), "invalid definitions")
+	}
+
+	for _, info := range infos {
+		if err := errors.RegisterCode(info); err != nil {
+			return errors.BadRequestFromError(err, "invalid definitions")
+		}
+	}
+	return nil
+}
+
+// render writes the catalog in the given format.
+func render(w io.Writer, format string, catalog []errors.CodeInfo) error {
+	var err error
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(catalog)
+	case "csv":
+		err = renderCSV(w, catalog)
+	case "ts":
+		err = renderTS(w, catalog)
+	default:
+		return errors.BadRequest(fmt.Sprintf("unknown format %q", format))
+	}
+
+	if err != nil {
+		return errors.InternalServerFromError(err, "rendering the catalog failed")
+	}
+	return nil
+}
+
+func renderCSV(w io.Writer, catalog []errors.CodeInfo) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"code", "error_id", "http_status", "msg", "retryable", "priority"})
+	for _, info := range catalog {
+		cw.Write([]string{
+			strconv.Itoa(int(info.Code)),
+			info.ID,
+			strconv.Itoa(info.HTTPStatus),
+			info.Message,
+			strconv.FormatBool(info.Retryable),
+			string(info.Priority),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func renderTS(w io.Writer, catalog []errors.CodeInfo) error {
+	var b strings.Builder
+	b.WriteString("// Code generated by errorscatalog. DO NOT EDIT.\n\n")
+
+	b.WriteString("export enum ErrorID {\n")
+	for _, info := range catalog {
+		fmt.Fprintf(&b, "  %s = %q,\n", pascal(info.ID), info.ID)
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("export enum StatusCode {\n")
+	for _, info := range catalog {
+		fmt.Fprintf(&b, "  %s = %d,\n", pascal(info.ID), info.Code)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// pascal returns the snake_case id in PascalCase, e.g. not_found is
+// returned as NotFound.
+func pascal(id string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(id, func(r rune) bool { return r == '_' || r == '-' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
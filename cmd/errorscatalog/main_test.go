@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Finciero/errors"
+)
+
+func TestRender(t *testing.T) {
+	catalog := []errors.CodeInfo{
+		{Code: errors.StatusNotFound, ID: "not_found", HTTPStatus: 404, Message: "not found"},
+		{Code: 1001, ID: "insufficient_funds", HTTPStatus: 402, Message: "insufficient, funds", Retryable: true, Priority: errors.PriorityTicket},
+	}
+
+	tests := []struct {
+		format string
+		exp    string
+	}{
+		{"csv", "code,error_id,http_status,msg,retryable,priority\n404,not_found,404,not found,false,\n1001,insufficient_funds,402,\"insufficient, funds\",true,ticket\n"},
+		{"ts", "// Code generated by errorscatalog. DO NOT EDIT.\n\nexport enum ErrorID {\n  NotFound = \"not_found\",\n  InsufficientFunds = \"insufficient_funds\",\n}\n\nexport enum StatusCode {\n  NotFound = 404,\n  InsufficientFunds = 1001,\n}\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := render(&buf, tt.format, catalog); err != nil {
+			t.Fatalf("render(%q) unexpected error: %v", tt.format, err)
+		}
+		if got := buf.String(); got != tt.exp {
+			t.Errorf("render(%q)\n exp: %s\n got: %s\n", tt.format, tt.exp, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, "json", catalog); err != nil || !strings.Contains(buf.String(), `"error_id": "insufficient_funds"`) {
+		t.Errorf("render(\"json\")\n exp: indented catalog\n got: %s (%v)\n", buf.String(), err)
+	}
+	if err := render(&buf, "xml", catalog); errors.CodeOf(err) != errors.StatusBadRequest {
+		t.Errorf("render(\"xml\")\n exp: bad_request error\n got: %v\n", err)
+	}
+	if err := render(failingWriter{}, "ts", catalog); errors.CodeOf(err) != errors.StatusInternalServerError {
+		t.Errorf("render() to a failing writer\n exp: internal_server error\n got: %v\n", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestRegister(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte(`{"code":`), 0o600)
+
+	tests := []struct {
+		path string
+		exp  errors.Code
+	}{
+		{filepath.Join(dir, "missing.json"), errors.StatusInternalServerError},
+		{invalid, errors.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		if got := errors.CodeOf(register(tt.path)); got != tt.exp {
+			t.Errorf("register(%s)\n exp: %d\n got: %d\n", filepath.Base(tt.path), tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CompareOption configures the comparison made by Equal.
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	ignoreStack         bool
+	ignoreInternalError bool
+	ignoreInternalMeta  bool
+	ignoreTimestamps    bool
+	ignoreMeta          map[string]bool
+}
+
+// IgnoreStack ignores the stack stored in InternalMeta.
+func IgnoreStack() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreStack = true
+	}
+}
+
+// IgnoreInternalError ignores the InternalError.
+func IgnoreInternalError() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreInternalError = true
+	}
+}
+
+// IgnoreMeta ignores the given Meta and InternalMeta keys.
+func IgnoreMeta(keys ...string) CompareOption {
+	return func(o *compareOptions) {
+		if o.ignoreMeta == nil {
+			o.ignoreMeta = map[string]bool{}
+		}
+		for _, key := range keys {
+			o.ignoreMeta[key] = true
+		}
+	}
+}
+
+// IgnoreInternal ignores the InternalError and InternalMeta.
+func IgnoreInternal() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreInternalError = true
+		o.ignoreInternalMeta = true
+	}
+}
+
+// IgnoreTimestamps ignores Meta and InternalMeta values that are timestamps,
+// either time.Time values or RFC 3339 strings.
+func IgnoreTimestamps() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreTimestamps = true
+	}
+}
+
+// Matcher returns a matcher of errors equal to want, compatible with
+// gomock.Matcher.
+//
+//	mock.EXPECT().Report(errors.Matcher(want, errors.IgnoreMeta("request_id")))
+func Matcher(want *Error, opts ...CompareOption) interface {
+	Matches(x interface{}) bool
+	String() string
+} {
+	return &matcher{want, opts}
+}
+
+type matcher struct {
+	want *Error
+	opts []CompareOption
+}
+
+func (m *matcher) Matches(x interface{}) bool {
+	got, ok := x.(*Error)
+	return ok && m.want.Equal(got, m.opts...)
+}
+
+func (m *matcher) String() string {
+	return fmt.Sprintf("is equal to %v", m.want)
+}
+
+// Equal reports whether e and other are semantically equal. Internal errors
+// are compared by their description.
+func (e *Error) Equal(other *Error, opts ...CompareOption) bool {
+	return len(diff(e, other, opts)) == 0
+}
+
+// Diff returns a field by field description of the differences between want
+// and got, one per line, or an empty string if they are equal.
+//
+//	if d := errors.Diff(want, got); d != "" {
+//		t.Errorf("unexpected error (-want +got):\n%s", d)
+//	}
+func Diff(want, got *Error, opts ...CompareOption) string {
+	return strings.Join(diff(want, got, opts), "\n")
+}
+
+// diff returns the differences between want and got.
+func diff(want, got *Error, opts []CompareOption) []string {
+	if want == nil || got == nil {
+		if want == got {
+			return nil
+		}
+		return []string{fmt.Sprintf("error: want %v, got %v", want, got)}
+	}
+
+	var o compareOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	var lines []string
+	if want.StatusCode != got.StatusCode {
+		lines = append(lines, fmt.Sprintf("status_code: want %d, got %d", want.StatusCode, got.StatusCode))
+	}
+	if want.Message != got.Message {
+		lines = append(lines, fmt.Sprintf("msg: want %q, got %q", want.Message, got.Message))
+	}
+	if want.UserMessage != got.UserMessage {
+		lines = append(lines, fmt.Sprintf("user_msg: want %q, got %q", want.UserMessage, got.UserMessage))
+	}
+	if want.FallbackAllowed != got.FallbackAllowed {
+		lines = append(lines, fmt.Sprintf("fallback_allowed: want %t, got %t", want.FallbackAllowed, got.FallbackAllowed))
+	}
+	if want.Priority != got.Priority {
+		lines = append(lines, fmt.Sprintf("priority: want %q, got %q", want.Priority, got.Priority))
+	}
+	if !o.ignoreInternalError && errorDesc(want.InternalError) != errorDesc(got.InternalError) {
+		lines = append(lines, fmt.Sprintf("cause: want %q, got %q", errorDesc(want.InternalError), errorDesc(got.InternalError)))
+	}
+	if w, g := suppressedDesc(want), suppressedDesc(got); w != g {
+		lines = append(lines, fmt.Sprintf("suppressed: want %q, got %q", w, g))
+	}
+
+	internalIgnored := o.ignoreMeta
+	if o.ignoreStack {
+		internalIgnored = map[string]bool{StackKey: true}
+		for key := range o.ignoreMeta {
+			internalIgnored[key] = true
+		}
+	}
+
+	lines = append(lines, metaDiff("meta", want.Meta, got.Meta, o.ignoreMeta, o.ignoreTimestamps)...)
+	if !o.ignoreInternalMeta {
+		lines = append(lines, metaDiff("internal_meta", want.InternalMeta, got.InternalMeta, internalIgnored, o.ignoreTimestamps)...)
+	}
+
+	return lines
+}
+
+// metaDiff returns the differences between the values of want and got,
+// ignoring the given keys and, optionally, timestamps.
+func metaDiff(name string, want, got Meta, ignored map[string]bool, ignoreTimestamps bool) []string {
+	keys := make([]string, 0, len(want)+len(got))
+	for key := range want {
+		keys = append(keys, key)
+	}
+	for key := range got {
+		if _, ok := want[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, key := range keys {
+		if ignored[key] {
+			continue
+		}
+
+		w, wok := want[key]
+		g, gok := got[key]
+		if ignoreTimestamps && (isTimestamp(w) || isTimestamp(g)) {
+			continue
+		}
+
+		switch {
+		case !gok:
+			lines = append(lines, fmt.Sprintf("%s.%s: want %#v, got <missing>", name, key, w))
+		case !wok:
+			lines = append(lines, fmt.Sprintf("%s.%s: want <missing>, got %#v", name, key, g))
+		case !reflect.DeepEqual(w, g):
+			lines = append(lines, fmt.Sprintf("%s.%s: want %#v, got %#v", name, key, w, g))
+		}
+	}
+	return lines
+}
+
+// isTimestamp reports whether v is a time.Time or an RFC 3339 string.
+func isTimestamp(v interface{}) bool {
+	switch v := v.(type) {
+	case time.Time, *time.Time:
+		return true
+	case string:
+		_, err := time.Parse(time.RFC3339Nano, v)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// suppressedDesc returns the descriptions of the suppressed errors of e.
+func suppressedDesc(e *Error) string {
+	descs := make([]string, len(e.suppressed))
+	for i, err := range e.suppressed {
+		descs[i] = errorDesc(err)
+	}
+	return strings.Join(descs, "; ")
+}
+
+// errorDesc returns the description of err, empty if nil.
+func errorDesc(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
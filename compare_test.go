@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b *Error
+		opts []CompareOption
+		exp  bool
+	}{
+		{nil, nil, nil, true},
+		{BadRequest("hi"), nil, nil, false},
+		{BadRequest("hi"), BadRequest("hi"), nil, true},
+		{BadRequest("hi"), BadRequest("ho"), nil, false},
+		{BadRequest("hi"), NotFound("hi"), nil, false},
+		{BadRequest("hi", SetMeta(Meta{"a": 1})), BadRequest("hi", SetMeta(Meta{"a": 1})), nil, true},
+		{BadRequest("hi", SetMeta(Meta{"a": 1})), BadRequest("hi", SetMeta(Meta{"a": 2})), nil, false},
+		{BadRequest("hi", SetMeta(Meta{"a": 1})), BadRequest("hi"), nil, false},
+		{BadRequest("hi", SetMeta(Meta{"a": 1})), BadRequest("hi"), []CompareOption{IgnoreMeta("a")}, true},
+		{BadRequestFromError(errors.New("x"), "hi"), BadRequestFromError(errors.New("x"), "hi"), nil, true},
+		{BadRequestFromError(errors.New("x"), "hi"), BadRequestFromError(errors.New("y"), "hi"), nil, false},
+		{BadRequestFromError(errors.New("x"), "hi"), BadRequest("hi"), []CompareOption{IgnoreInternalError()}, true},
+		{
+			a:   InternalServer("", SetInternalMeta(Meta{StackKey: "a"})),
+			b:   InternalServer("", SetInternalMeta(Meta{StackKey: "b"})),
+			exp: false,
+		},
+		{
+			a:    InternalServer("", SetInternalMeta(Meta{StackKey: "a"})),
+			b:    InternalServer("", SetInternalMeta(Meta{StackKey: "b"})),
+			opts: []CompareOption{IgnoreStack()},
+			exp:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b, tt.opts...); got != tt.exp {
+			t.Errorf("(%v).Equal(%v)\n exp: %v\n got: %v\n", tt.a, tt.b, tt.exp, got)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		want, got *Error
+		exp       string
+	}{
+		{BadRequest("hi"), BadRequest("hi"), ""},
+		{BadRequest("hi"), nil, `error: want status_code=400 error_id="bad_request" msg="hi", got <nil>`},
+		{
+			want: NotFound("no account", SetMeta(Meta{"account": "a1", "bank": 1})),
+			got:  BadRequestFromError(errors.New("x"), "no account", SetMeta(Meta{"account": "a2", "user": "u1"})),
+			exp: `status_code: want 404, got 400
+cause: want "", got "x"
+meta.account: want "a1", got "a2"
+meta.bank: want 1, got <missing>
+meta.user: want <missing>, got "u1"`,
+		},
+		{InternalServer("x", SetPriority(PriorityTicket)), InternalServer("x"), `priority: want "ticket", got ""`},
+	}
+
+	for _, tt := range tests {
+		if got := Diff(tt.want, tt.got); got != tt.exp {
+			t.Errorf("Diff(%v, %v)\n exp: %q\n got: %q\n", tt.want, tt.got, tt.exp, got)
+		}
+	}
+}
+
+func TestCompareOptions(t *testing.T) {
+	tests := []struct {
+		a, b *Error
+		opts []CompareOption
+		exp  bool
+	}{
+		{
+			a:   BadRequest("", SetMeta(Meta{"at": time.Now()})),
+			b:   BadRequest("", SetMeta(Meta{"at": "2016-01-02T15:04:05Z"})),
+			exp: false,
+		},
+		{
+			a:    BadRequest("", SetMeta(Meta{"at": time.Now()})),
+			b:    BadRequest("", SetMeta(Meta{"at": "2016-01-02T15:04:05Z"})),
+			opts: []CompareOption{IgnoreTimestamps()},
+			exp:  true,
+		},
+		{
+			a:    BadRequest("", SetMeta(Meta{"at": "yesterday"})),
+			b:    BadRequest("", SetMeta(Meta{"at": "2016-01-02T15:04:05Z"})),
+			opts: []CompareOption{IgnoreTimestamps()},
+			exp:  true,
+		},
+		{
+			a:    BadRequest("", SetMeta(Meta{"n": 1})),
+			b:    BadRequest("", SetMeta(Meta{"n": 2})),
+			opts: []CompareOption{IgnoreTimestamps()},
+			exp:  false,
+		},
+		{
+			a:    BadRequestFromError(errors.New("x"), "", SetInternalMeta(Meta{"q": 1})),
+			b:    BadRequest(""),
+			opts: []CompareOption{IgnoreInternal()},
+			exp:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b, tt.opts...); got != tt.exp {
+			t.Errorf("(%v).Equal(%v)\n exp: %v\n got: %v\n", tt.a, tt.b, tt.exp, got)
+		}
+		if got := Matcher(tt.a, tt.opts...).Matches(tt.b); got != tt.exp {
+			t.Errorf("Matcher(%v).Matches(%v)\n exp: %v\n got: %v\n", tt.a, tt.b, tt.exp, got)
+		}
+	}
+}
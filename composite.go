@@ -0,0 +1,36 @@
+package errors
+
+import "errors"
+
+// Errors returns the sub-errors of a composite error, i.e. one whose
+// InternalError joins multiple errors like errors.Join does, e.g. field
+// validation errors. Sub-errors that are not an *Error are converted with
+// BuildError. It returns nil if the error is not composite.
+//
+//	errors.InvalidParamsFromError(stderrors.Join(
+//		errors.BadRequest("required", errors.SetMeta(errors.Meta{"field": "name"})),
+//		errors.BadRequest("too long", errors.SetMeta(errors.Meta{"field": "bio"})),
+//	), "invalid params")
+func (e *Error) Errors() []*Error {
+	multi, ok := e.InternalError.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+
+	var errs []*Error
+	for _, err := range multi.Unwrap() {
+		if sub := BuildError(err); sub != nil {
+			errs = append(errs, sub)
+		}
+	}
+	return errs
+}
+
+// joinErrors returns the composite internal error of the given sub-errors.
+func joinErrors(subs []*Error) error {
+	errs := make([]error, len(subs))
+	for i, sub := range subs {
+		errs[i] = sub
+	}
+	return errors.Join(errs...)
+}
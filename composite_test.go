@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+)
+
+func TestCompositeJSON(t *testing.T) {
+	e := InvalidParamsFromError(stderrors.Join(
+		BadRequest("required", SetMeta(Meta{"field": "name"})),
+		stderrors.New("db down"),
+	), "invalid params")
+
+	got, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+
+	exp := `{"msg":"invalid params","error_id":"invalid_params","status_code":422,"errors":[` +
+		`{"meta":{"field":"name"},"msg":"required","error_id":"bad_request","status_code":400},` +
+		`{"msg":"unexpected error","error_id":"internal_server","status_code":500}]}`
+	if string(got) != exp {
+		t.Errorf("Marshal()\n exp: %s\n got: %s\n", exp, got)
+	}
+
+	var decoded Error
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	subs := decoded.Errors()
+	if len(subs) != 2 || subs[0].Meta["field"] != "name" || subs[1].StatusCode != StatusInternalServerError {
+		t.Errorf("Unmarshal().Errors()\n exp: %v\n got: %v\n", e.Errors(), subs)
+	}
+
+	if subs := BadRequest("plain").Errors(); subs != nil {
+		t.Errorf("Errors() of a non composite error\n exp: nil\n got: %v\n", subs)
+	}
+}
@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"html/template"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// Config is the package configuration. It is installed as a whole with
+// SetConfig and never modified afterwards, so it can be swapped at runtime,
+// e.g. to enable Debug mode from an admin endpoint, without racing with the
+// errors being serialized. The individual setters, like SetMode or AddSink,
+// install a modified copy of the current Config.
+type Config struct {
+	// Mode is the serialization mode, see SetMode.
+	Mode Mode
+	// CaptureStacks enables capturing stacks, see CaptureStacks.
+	CaptureStacks bool
+	// StackSampling is the fraction of the stacks captured, see
+	// SampleStacks.
+	StackSampling float64
+	// MaxMessageLength limits the length of messages, see
+	// SetMaxMessageLength.
+	MaxMessageLength int
+	// Naming is the json naming style, see SetNaming.
+	Naming Naming
+	// CanonicalCodes enables the canonical grpc codes, see
+	// UseCanonicalCodes.
+	CanonicalCodes bool
+	// WireFormat is the default grpc wire format, see SetWireFormat.
+	WireFormat WireFormat
+	// Domain is the domain of the grpc ErrorInfo details, see SetDomain.
+	Domain string
+	// Scrubbers are applied before errors are serialized, reported or
+	// logged, see AddScrubber.
+	Scrubbers []Scrubber
+	// Sinks receive the reported errors, see AddSink.
+	Sinks []Sink
+	// Logger logs the written errors, see SetLogger.
+	Logger Logger
+	// ErrorPage renders the html error pages, see SetErrorPage.
+	ErrorPage *template.Template
+	// LocaleFallbacks are the languages tried by Localize, see
+	// SetLocaleFallback.
+	LocaleFallbacks map[language.Tag][]language.Tag
+	// MissingTranslation is the policy of Localize for errors without a
+	// translation, see SetMissingTranslation.
+	MissingTranslation MissingTranslation
+}
+
+var (
+	config   = initialConfig() // holds a *Config
+	configMu sync.Mutex        // serializes updates
+)
+
+// initialConfig returns the configuration in effect at startup. It runs as a
+// package variable initializer, so the configuration is set before any init
+// function runs.
+func initialConfig() *atomic.Value {
+	var v atomic.Value
+	v.Store(&Config{Mode: modeFromEnv(), Domain: "finciero.com"})
+	return &v
+}
+
+// CurrentConfig returns a copy of the current configuration.
+func CurrentConfig() Config {
+	return loadConfig().clone()
+}
+
+// SetConfig atomically replaces the configuration with a copy of c.
+func SetConfig(c Config) {
+	configMu.Lock()
+	c = c.clone()
+	config.Store(&c)
+	configMu.Unlock()
+}
+
+// UpdateConfig atomically replaces the configuration with a copy of the
+// current one modified by fn. Concurrent updates are applied in turn.
+//
+//	errors.UpdateConfig(func(c *errors.Config) {
+//		c.Mode = errors.Debug
+//		c.CaptureStacks = true
+//	})
+func UpdateConfig(fn func(*Config)) {
+	configMu.Lock()
+	c := loadConfig().clone()
+	fn(&c)
+	config.Store(&c)
+	configMu.Unlock()
+}
+
+// loadConfig returns the current configuration, which must not be modified.
+func loadConfig() *Config {
+	return config.Load().(*Config)
+}
+
+// clone returns a copy of c not sharing its slices.
+func (c *Config) clone() Config {
+	clone := *c
+	clone.Scrubbers = append([]Scrubber(nil), c.Scrubbers...)
+	clone.Sinks = append([]Sink(nil), c.Sinks...)
+	if c.LocaleFallbacks != nil {
+		clone.LocaleFallbacks = make(map[language.Tag][]language.Tag, len(c.LocaleFallbacks))
+		for tag, fallbacks := range c.LocaleFallbacks {
+			clone.LocaleFallbacks[tag] = append([]language.Tag(nil), fallbacks...)
+		}
+	}
+	return clone
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"sync"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestConfig(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+
+	SetConfig(Config{Mode: Debug, Naming: CamelCase, Domain: "example.com"})
+	SetMaxMessageLength(10)
+
+	exp := Config{Mode: Debug, Naming: CamelCase, Domain: "example.com", MaxMessageLength: 10}
+	got := CurrentConfig()
+	if got.Mode != exp.Mode || got.Naming != exp.Naming || got.Domain != exp.Domain || got.MaxMessageLength != exp.MaxMessageLength {
+		t.Errorf("CurrentConfig()\n exp: %+v\n got: %+v\n", exp, got)
+	}
+
+	got.Sinks = append(got.Sinks, &testSink{})
+	if n := len(CurrentConfig().Sinks); n != 0 {
+		t.Errorf("CurrentConfig() shares its sinks\n exp: 0\n got: %d\n", n)
+	}
+
+	SetLocaleFallback(language.Spanish, language.English)
+	got = CurrentConfig()
+	got.LocaleFallbacks[language.Spanish][0] = language.French
+	got.LocaleFallbacks[language.French] = nil
+	if fallbacks := CurrentConfig().LocaleFallbacks; len(fallbacks) != 1 || fallbacks[language.Spanish][0] != language.English {
+		t.Errorf("CurrentConfig() shares its locale fallbacks\n exp: map[es:[en]]\n got: %v\n", fallbacks)
+	}
+}
+
+func TestConfigConcurrent(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			UpdateConfig(func(c *Config) { c.Mode = Debug })
+			AddScrubber(ScrubKeys("password"))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = NotFound("no account", SetMeta(Meta{"password": "secret"})).MarshalJSON()
+		}()
+	}
+	wg.Wait()
+
+	if n := len(CurrentConfig().Scrubbers); n != 8 {
+		t.Errorf("UpdateConfig() concurrent scrubbers\n exp: 8\n got: %d\n", n)
+	}
+}
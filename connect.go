@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToConnect encodes error into a connect error. StatusCode and Meta are
+// carried as an error detail so FromConnect can restore them. The registered
+// scrubbers are applied first.
+func (e *Error) ToConnect() *connect.Error {
+	e = scrub(e)
+	cerr := connect.NewError(connect.Code(e.StatusCode.Canonical()), errors.New(e.Message))
+
+	buff, _ := json.Marshal(struct {
+		Meta       Meta `json:"meta,omitempty"`
+		StatusCode Code `json:"status_code"`
+	}{e.Meta, e.StatusCode})
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buff, &fields); err != nil {
+		return cerr
+	}
+
+	details, err := structpb.NewStruct(fields)
+	if err != nil {
+		return cerr
+	}
+
+	if detail, err := connect.NewErrorDetail(details); err == nil {
+		cerr.AddDetail(detail)
+	}
+
+	return cerr
+}
+
+// FromConnect returns a new Error from an error received by connect. If the
+// error was encoded with ToConnect method then the full Error passed is
+// returned.
+func FromConnect(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		return BuildError(err)
+	}
+
+	e := &Error{
+		StatusCode: CodeFromCanonical(codes.Code(cerr.Code())),
+		Message:    cerr.Message(),
+	}
+
+	for _, detail := range cerr.Details() {
+		value, err := detail.Value()
+		if err != nil {
+			continue
+		}
+
+		s, ok := value.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+
+		var raw struct {
+			Meta       Meta `json:"meta,omitempty"`
+			StatusCode Code `json:"status_code"`
+		}
+		buff, _ := s.MarshalJSON()
+		if json.Unmarshal(buff, &raw) != nil || raw.StatusCode == 0 {
+			continue
+		}
+
+		e.StatusCode = raw.StatusCode
+		e.Meta = raw.Meta
+		break
+	}
+
+	return e
+}
@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToConnectFromConnect(t *testing.T) {
+	tests := []struct {
+		err *Error
+	}{
+		{BadRequest("let's go", SetMeta(Meta{"hi": "ho"}))},
+		{Delinquent("pay up")},
+		{RateLimit("")},
+		{New(418, "teapot")},
+	}
+
+	for _, tt := range tests {
+		in := tt.err.ToConnect()
+		err := FromConnect(in)
+
+		if !reflect.DeepEqual(err, tt.err) {
+			t.Errorf("FromConnect(%v) = %v\n exp: %v\n got: %v\n", in, err, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	contextMetaMu sync.RWMutex
+	contextMeta   []func(context.Context) Meta
+)
+
+// RegisterContextMeta registers fn to derive Meta from the request context,
+// e.g. the user, tenant or request id. The derived Meta is added to errors
+// created with SetContextMeta, returned by the grpc interceptors or written
+// by WriteHTTPRequest. Values set explicitly in the error take precedence.
+//
+//	errors.RegisterContextMeta(func(ctx context.Context) errors.Meta {
+//		return errors.Meta{"request_id": requestid.From(ctx)}
+//	})
+func RegisterContextMeta(fn func(context.Context) Meta) {
+	contextMetaMu.Lock()
+	contextMeta = append(contextMeta, fn)
+	contextMetaMu.Unlock()
+}
+
+// metaFromContext returns the Meta derived from ctx by the registered
+// functions.
+func metaFromContext(ctx context.Context) Meta {
+	contextMetaMu.RLock()
+	defer contextMetaMu.RUnlock()
+
+	if ctx == nil || len(contextMeta) == 0 {
+		return nil
+	}
+
+	meta := Meta{}
+	for _, fn := range contextMeta {
+		for key, value := range fn(ctx) {
+			meta[key] = value
+		}
+	}
+	return meta
+}
+
+// SetContextMeta sets into the Meta of the error the values derived from ctx
+// by the functions registered with RegisterContextMeta.
+func SetContextMeta(ctx context.Context) errorParamsSetter {
+	return func(e *Error) {
+		for key, value := range metaFromContext(ctx) {
+			if e.Meta == nil {
+				e.Meta = Meta{}
+			}
+			if _, ok := e.Meta[key]; !ok {
+				e.Meta[key] = value
+			}
+		}
+	}
+}
+
+// withContextMeta returns a copy of e with the Meta derived from ctx, or e
+// itself if nothing is derived.
+func (e *Error) withContextMeta(ctx context.Context) *Error {
+	meta := metaFromContext(ctx)
+	if len(meta) == 0 {
+		return e
+	}
+
+	c := e.copy()
+	SetContextMeta(ctx)(c)
+	return c
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+type requestIDKey struct{}
+
+func TestContextMeta(t *testing.T) {
+	RegisterContextMeta(func(ctx context.Context) Meta {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return Meta{"request_id": id}
+		}
+		return nil
+	})
+	defer func() {
+		contextMeta = nil
+	}()
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "r1")
+
+	tests := []struct {
+		err *Error
+		exp Meta
+	}{
+		{BadRequest("", SetContextMeta(ctx)), Meta{"request_id": "r1"}},
+		{BadRequest("", SetContextMeta(ctx), SetMeta(Meta{"a": 1})), Meta{"request_id": "r1", "a": 1}},
+		{BadRequest("", SetMeta(Meta{"request_id": "explicit"}), SetContextMeta(ctx)), Meta{"request_id": "explicit"}},
+		{BadRequest("", SetContextMeta(context.Background())), nil},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.err.Meta, tt.exp) {
+			t.Errorf("SetContextMeta()\n exp: %v\n got: %v\n", tt.exp, tt.err.Meta)
+		}
+	}
+
+	shared := NotFound("no account")
+	interceptor := UnaryServerInterceptor()
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, shared
+	})
+	if got := FromGRPC(err); got.Meta["request_id"] != "r1" || shared.Meta != nil {
+		t.Errorf("UnaryServerInterceptor() meta\n exp: request_id=r1\n got: %v (shared %v)\n", got.Meta, shared.Meta)
+	}
+
+	rec := httptest.NewRecorder()
+	WriteHTTPRequest(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), shared)
+	if got := FromHTTPResponse(rec.Result()); got.Meta["request_id"] != "r1" {
+		t.Errorf("WriteHTTPRequest() meta\n exp: request_id=r1\n got: %v\n", got.Meta)
+	}
+}
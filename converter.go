@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Converter converts errors of other packages into an *Error, reporting
+// whether it recognized err.
+type Converter func(err error) (*Error, bool)
+
+var (
+	convertersMu sync.RWMutex
+	converters   []Converter
+)
+
+// RegisterConverter registers c to be used by BuildError and CodeOf for
+// errors that are not an *Error. Converters are tried in registration order.
+//
+//	errors.RegisterConverter(func(err error) (*errors.Error, bool) {
+//		if err == sql.ErrNoRows {
+//			return errors.NotFoundFromError(err, "not found"), true
+//		}
+//		return nil, false
+//	})
+func RegisterConverter(c Converter) {
+	convertersMu.Lock()
+	converters = append(converters, c)
+	convertersMu.Unlock()
+}
+
+// convert returns the *Error of the first registered converter recognizing
+// err, falling back to the built-in conversion of context errors.
+func convert(err error) (*Error, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	for _, c := range converters {
+		if e, ok := c(err); ok && e != nil {
+			return e, true
+		}
+	}
+	return convertContext(err)
+}
+
+// convertContext converts context.Canceled into a client_closed_request
+// error and context.DeadlineExceeded into a request_timeout one, so they are
+// not taken for server errors.
+func convertContext(err error) (*Error, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ClientClosedRequestFromError(err, "request canceled"), true
+	case errors.Is(err, context.DeadlineExceeded):
+		return RequestTimeoutFromError(err, "request timeout"), true
+	}
+	return nil, false
+}
+
+// CodeOf returns the code of err, looking through its cause chain for an
+// *Error, a gRPC status or an error recognized by a registered converter.
+// It returns StatusInternalServerError if there is none, and 0 if err is nil.
+func CodeOf(err error) Code {
+	if err == nil {
+		return 0
+	}
+
+	for cur, i := err, 0; cur != nil && i < maxChainDepth; cur, i = unwrap(cur), i+1 {
+		if e, ok := cur.(*Error); ok {
+			return e.StatusCode
+		}
+	}
+
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK && s.Code() != codes.Unknown {
+		if code, ok := detailCode(s); ok {
+			return code
+		}
+		return codeFromGRPC(s.Code())
+	}
+
+	if e, ok := convert(err); ok {
+		return e.StatusCode
+	}
+
+	return StatusInternalServerError
+}
+
+// codeFromGRPC returns the status code of a gRPC code, which is either one of
+// our codes encoded by ToGRPC or a canonical one.
+func codeFromGRPC(c codes.Code) Code {
+	if c > codes.Unauthenticated {
+		return Code(c)
+	}
+	return CodeFromCanonical(c)
+}
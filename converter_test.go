@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCodeOf(t *testing.T) {
+	RegisterConverter(func(err error) (*Error, bool) {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NotFoundFromError(err, "not found"), true
+		}
+		return nil, false
+	})
+	defer func() {
+		converters = nil
+	}()
+
+	tests := []struct {
+		err error
+		exp Code
+	}{
+		{nil, 0},
+		{NotFound(""), StatusNotFound},
+		{fmt.Errorf("get: %w", RateLimit("")), StatusTooManyRequests},
+		{Forbidden("").ToGRPC(), StatusForbidden},
+		{status.Error(codes.NotFound, "no account"), StatusNotFound},
+		{status.Error(codes.Unavailable, "down"), StatusInternalServerError},
+		{fmt.Errorf("query: %w", sql.ErrNoRows), StatusNotFound},
+		{io.EOF, StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.exp {
+			t.Errorf("CodeOf(%v)\n exp: %d\n got: %d\n", tt.err, tt.exp, got)
+		}
+	}
+
+	if got := BuildError(sql.ErrNoRows); got.StatusCode != StatusNotFound {
+		t.Errorf("BuildError(%v) status\n exp: %d\n got: %d\n", sql.ErrNoRows, StatusNotFound, got.StatusCode)
+	}
+}
+
+func TestConvertContext(t *testing.T) {
+	tests := []struct {
+		err error
+		exp Code
+	}{
+		{context.Canceled, StatusClientClosedRequest},
+		{fmt.Errorf("query: %w", context.Canceled), StatusClientClosedRequest},
+		{status.Error(codes.Canceled, "canceled"), StatusClientClosedRequest},
+	}
+
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.exp {
+			t.Errorf("CodeOf(%v)\n exp: %d\n got: %d\n", tt.err, tt.exp, got)
+		}
+		if got := HTTPStatusOf(tt.err); got != 499 {
+			t.Errorf("HTTPStatusOf(%v)\n exp: 499\n got: %d\n", tt.err, got)
+		}
+	}
+
+	if got := BuildError(context.Canceled); !errors.Is(got, ErrClientClosedRequest) {
+		t.Errorf("BuildError(context.Canceled)\n exp: %v\n got: %v\n", ErrClientClosedRequest, got)
+	}
+}
+
+func TestConvertDeadline(t *testing.T) {
+	tests := []struct {
+		err error
+		exp Code
+	}{
+		{context.DeadlineExceeded, StatusRequestTimeout},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), StatusRequestTimeout},
+		{status.Error(codes.DeadlineExceeded, "deadline"), StatusRequestTimeout},
+	}
+
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.exp {
+			t.Errorf("CodeOf(%v)\n exp: %d\n got: %d\n", tt.err, tt.exp, got)
+		}
+	}
+
+	if got := RequestTimeout("upstream timeout").StatusCode.Canonical(); got != codes.DeadlineExceeded {
+		t.Errorf("Canonical()\n exp: %v\n got: %v\n", codes.DeadlineExceeded, got)
+	}
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Meta keys set by SetDeadline. Durations are strings like "1.5s".
+const (
+	DeadlineKey        = "deadline"         // deadline of the context, in RFC 3339
+	DeadlineBudgetKey  = "deadline_budget"  // time between the start and the deadline
+	ElapsedKey         = "elapsed"          // time elapsed since the start
+	RemainingBudgetKey = "remaining_budget" // time left until the deadline, negative once exceeded
+)
+
+type startKey struct{}
+
+// WithStart returns a copy of ctx recording now as the start of the request,
+// from which SetDeadline measures the elapsed time and budget.
+// UnaryServerInterceptor records it for every request.
+func WithStart(ctx context.Context) context.Context {
+	return context.WithValue(ctx, startKey{}, now())
+}
+
+// startOf returns the start of the request recorded by WithStart.
+func startOf(ctx context.Context) (time.Time, bool) {
+	start, ok := ctx.Value(startKey{}).(time.Time)
+	return start, ok
+}
+
+// SetDeadline sets into the Meta of the error the deadline of ctx and the
+// remaining budget, plus the deadline budget and elapsed time if the start
+// of the request was recorded with WithStart. Nothing is set if ctx has no
+// deadline.
+func SetDeadline(ctx context.Context) errorParamsSetter {
+	return func(e *Error) {
+		if ctx == nil {
+			return
+		}
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return
+		}
+
+		current := now()
+		meta := Meta{
+			DeadlineKey:        deadline.UTC().Format(time.RFC3339Nano),
+			RemainingBudgetKey: deadline.Sub(current).String(),
+		}
+		if start, ok := startOf(ctx); ok {
+			meta[DeadlineBudgetKey] = deadline.Sub(start).String()
+			meta[ElapsedKey] = current.Sub(start).String()
+		}
+		SetMetaNoCopy(meta)(e)
+	}
+}
+
+// FromContext returns the error of ctx once it is done, nil otherwise: a
+// client_closed_request error if it was canceled or a request_timeout one
+// with the deadline set by SetDeadline if it expired.
+//
+//	select {
+//	case <-ctx.Done():
+//		return errors.FromContext(ctx)
+//	case res := <-results:
+//		...
+//	}
+func FromContext(ctx context.Context) *Error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return RequestTimeoutFromError(err, "request timeout", SetDeadline(ctx))
+	}
+	return ClientClosedRequestFromError(err, "request canceled")
+}
+
+// withDeadline returns a copy of a request_timeout error with the deadline
+// of ctx set, see SetDeadline, or e itself if it is not a request_timeout
+// error, already has it or ctx has no deadline.
+func (e *Error) withDeadline(ctx context.Context) *Error {
+	if e.StatusCode != StatusRequestTimeout {
+		return e
+	}
+	if _, ok := e.Meta[DeadlineKey]; ok {
+		return e
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return e
+	}
+
+	c := e.copy()
+	SetDeadline(ctx)(c)
+	return c
+}
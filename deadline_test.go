@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFromContext(t *testing.T) {
+	start := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	current := start
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	ctx := WithStart(context.Background())
+	if got := FromContext(ctx); got != nil {
+		t.Errorf("FromContext(active)\n exp: <nil>\n got: %v\n", got)
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, start.Add(2*time.Second))
+	defer cancel()
+	current = start.Add(3 * time.Second)
+
+	exp := RequestTimeout("request timeout", SetMeta(Meta{
+		DeadlineKey:        "2020-01-01T10:00:02Z",
+		DeadlineBudgetKey:  "2s",
+		ElapsedKey:         "3s",
+		RemainingBudgetKey: "-1s",
+	}))
+	if got := FromContext(ctx); !got.Equal(exp, IgnoreInternalError()) {
+		t.Errorf("FromContext(expired)\n%s", Diff(exp, got, IgnoreInternalError()))
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := FromContext(canceled); got.StatusCode != StatusClientClosedRequest || len(got.Meta) != 0 {
+		t.Errorf("FromContext(canceled)\n exp: %v\n got: %v\n", ClientClosedRequest("request canceled"), got)
+	}
+
+	if got := RequestTimeout("slow bank").withDeadline(ctx); got.Meta[ElapsedKey] != "3s" {
+		t.Errorf("withDeadline()\n exp: %s=3s\n got: %v\n", ElapsedKey, got.Meta)
+	}
+	if got := NotFound("").withDeadline(ctx); len(got.Meta) != 0 {
+		t.Errorf("withDeadline(not_found)\n exp: no meta\n got: %v\n", got.Meta)
+	}
+}
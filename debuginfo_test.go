@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDebugInfoDetail(t *testing.T) {
+	CaptureStacks(true)
+	defer CaptureStacks(false)
+
+	e := InternalServerFromError(errors.New("db down"), "unexpected error")
+
+	tests := []struct {
+		mode Mode
+		exp  bool
+	}{
+		{Production, false},
+		{Debug, true},
+	}
+
+	for _, tt := range tests {
+		SetMode(tt.mode)
+
+		var debug *errdetails.DebugInfo
+		for _, d := range e.GRPCStatus().Details() {
+			if d, ok := d.(*errdetails.DebugInfo); ok {
+				debug = d
+			}
+		}
+
+		if got := debug != nil; got != tt.exp {
+			t.Errorf("GRPCStatus() DebugInfo in mode %d\n exp: %v\n got: %v\n", tt.mode, tt.exp, debug)
+			continue
+		}
+		if debug != nil && (debug.Detail != "db down" || !strings.Contains(debug.StackEntries[0], "TestDebugInfoDetail")) {
+			t.Errorf("GRPCStatus() DebugInfo\n exp: db down at TestDebugInfoDetail\n got: %v\n", debug)
+		}
+	}
+	SetMode(Production)
+
+	foreign, _ := status.New(codes.Internal, "internal").WithDetails(
+		&errdetails.ErrorInfo{Reason: "INTERNAL"},
+		&errdetails.DebugInfo{Detail: "nil pointer", StackEntries: []string{"main.handler main.go:10", "main.main main.go:3"}},
+	)
+
+	got := FromGRPC(foreign.Err())
+	if got.InternalError == nil || got.InternalError.Error() != "nil pointer" || got.InternalMeta[StackKey] != "main.handler main.go:10\nmain.main main.go:3" {
+		t.Errorf("FromGRPC(DebugInfo)\n exp: nil pointer with stack\n got: %v %v\n", got.InternalError, got.InternalMeta)
+	}
+}
@@ -0,0 +1,116 @@
+package errors
+
+import "fmt"
+
+// Meta keys set from the defaults of the code, see SetCodeDefaults.
+const (
+	SeverityKey = "severity"
+	HelpURLKey  = "help_url"
+)
+
+// Severity is the impact of the errors of a code.
+type Severity string
+
+// Severities
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+// CodeDefaults are the defaults shared by the errors of a code. Only the
+// fields set are applied, the others keep the value of the code.
+type CodeDefaults struct {
+	// Meta is merged into the Meta of the errors, the values given at
+	// construction taking precedence.
+	Meta Meta
+	// Retryable tells whether the operation may succeed if retried, see
+	// IsRetryable, nil to keep the value of the code.
+	Retryable *bool
+	// Severity is set into the Meta of the errors under SeverityKey and sets
+	// the level at which they are logged, see SetLogger.
+	Severity Severity
+	// HelpURL is set into the Meta of the errors under HelpURLKey, e.g. the
+	// documentation of the error.
+	HelpURL string
+	// Priority is the default Priority of the errors, see PriorityOf.
+	Priority Priority
+}
+
+// SetCodeDefaults sets the defaults of a registered code, built-in or
+// custom, applied by the constructors so construction sites do not repeat
+// them. It fails if the code is not registered.
+//
+//	retryable := true
+//	errors.SetCodeDefaults(errors.StatusTooManyRequests, errors.CodeDefaults{
+//		Retryable: &retryable,
+//		Severity:  errors.SeverityWarning,
+//		HelpURL:   "https://docs.finciero.com/errors/too_many_requests",
+//	})
+func SetCodeDefaults(c Code, d CodeDefaults) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	info, ok := registry.codes[c]
+	if !ok {
+		return fmt.Errorf("errors: code %d not registered", c)
+	}
+	if d.Meta != nil {
+		info.Meta = d.Meta
+	}
+	if d.Retryable != nil {
+		info.Retryable = *d.Retryable
+	}
+	if len(d.Severity) > 0 {
+		info.Severity = d.Severity
+	}
+	if len(d.HelpURL) > 0 {
+		info.HelpURL = d.HelpURL
+	}
+	if len(d.Priority) > 0 {
+		info.Priority = d.Priority
+	}
+	registry.codes[c] = info
+
+	return nil
+}
+
+// IsRetryable reports whether the code of err, as resolved by CodeOf, is
+// registered as retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	info, ok := lookupCode(CodeOf(err))
+	return ok && info.Retryable
+}
+
+// applyCodeDefaults merges the default Meta, severity and help url of the
+// code into the Meta of the error, setting only the keys it lacks. The Meta
+// is owned by the error at this point, SetMeta copies the given one and
+// SetMetaNoCopy adopts it, so it is updated in place.
+func (e *Error) applyCodeDefaults() {
+	info, ok := lookupCode(e.StatusCode)
+	if !ok || (len(info.Meta) == 0 && len(info.Severity) == 0 && len(info.HelpURL) == 0) {
+		return
+	}
+
+	if e.Meta == nil {
+		e.Meta = make(Meta, len(info.Meta)+2)
+	}
+	setDefault := func(key string, value interface{}) {
+		if _, ok := e.Meta[key]; !ok {
+			e.Meta[key] = value
+		}
+	}
+	for key, value := range info.Meta {
+		setDefault(key, value)
+	}
+	if len(info.Severity) > 0 {
+		setDefault(SeverityKey, string(info.Severity))
+	}
+	if len(info.HelpURL) > 0 {
+		setDefault(HelpURLKey, info.HelpURL)
+	}
+}
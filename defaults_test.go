@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSetCodeDefaults(t *testing.T) {
+	info, _ := lookupCode(StatusTooManyRequests)
+	defer func() {
+		registry.Lock()
+		registry.codes[StatusTooManyRequests] = info
+		registry.Unlock()
+	}()
+
+	err := SetCodeDefaults(StatusTooManyRequests, CodeDefaults{
+		Meta:     Meta{"limit": 10, "scope": "account"},
+		Severity: SeverityWarning,
+		HelpURL:  "https://docs.finciero.com/errors/too_many_requests",
+	})
+	if err != nil {
+		t.Fatalf("SetCodeDefaults() unexpected error: %v", err)
+	}
+	if got, _ := lookupCode(StatusTooManyRequests); !got.Retryable {
+		t.Errorf("SetCodeDefaults() without Retryable\n exp: %t\n got: %t\n", true, got.Retryable)
+	}
+	retryable := false
+	SetCodeDefaults(StatusTooManyRequests, CodeDefaults{Retryable: &retryable})
+	if got, _ := lookupCode(StatusTooManyRequests); got.Retryable || got.Severity != SeverityWarning {
+		t.Errorf("SetCodeDefaults() with Retryable only\n exp: %t %s\n got: %t %s\n", false, SeverityWarning, got.Retryable, got.Severity)
+	}
+	retryable = true
+	SetCodeDefaults(StatusTooManyRequests, CodeDefaults{Retryable: &retryable, Priority: PriorityPage})
+	if got := PriorityOf(RateLimit("slow down")); got != PriorityPage {
+		t.Errorf("PriorityOf() with defaults\n exp: %s\n got: %s\n", PriorityPage, got)
+	}
+	if err := SetCodeDefaults(4, CodeDefaults{}); err == nil {
+		t.Errorf("SetCodeDefaults(4) expected error for an unregistered code")
+	}
+
+	shared := Meta{"limit": 5}
+	e := RateLimit("slow down", SetMeta(shared))
+
+	exp := Meta{
+		"limit":     5,
+		"scope":     "account",
+		SeverityKey: "warning",
+		HelpURLKey:  "https://docs.finciero.com/errors/too_many_requests",
+	}
+	if d := metaDiff("meta", exp, e.Meta, nil, false); len(d) > 0 {
+		t.Errorf("RateLimit() with defaults\n%v", d)
+	}
+	if len(shared) != 1 {
+		t.Errorf("RateLimit() with defaults modified the meta given to SetMeta: %v", shared)
+	}
+
+	adopted := Meta{"limit": 5}
+	e = RateLimit("slow down", SetMetaNoCopy(adopted))
+	if reflect.ValueOf(e.Meta).Pointer() != reflect.ValueOf(adopted).Pointer() || adopted["scope"] != "account" || adopted["limit"] != 5 {
+		t.Errorf("RateLimit() with defaults did not keep the meta given to SetMetaNoCopy: %v", e.Meta)
+	}
+
+	tests := []struct {
+		err error
+		exp bool
+	}{
+		{nil, false},
+		{e, true},
+		{fmt.Errorf("call: %w", e), true},
+		{NotFound(""), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.exp {
+			t.Errorf("IsRetryable(%v)\n exp: %t\n got: %t\n", tt.err, tt.exp, got)
+		}
+	}
+}
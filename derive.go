@@ -0,0 +1,109 @@
+package errors
+
+import "reflect"
+
+// maxCopyDepth limits the nesting of the Meta values copied by Clone, deeper
+// values are shared.
+const maxCopyDepth = 32
+
+// Clone returns a deep copy of the error: its Meta and InternalMeta maps are
+// copied along with the maps and slices nested in their values, so neither
+// can be modified through the other. Other references, like nested errors,
+// are shared.
+func (e *Error) Clone() *Error {
+	c := e.copy()
+	for _, meta := range []Meta{c.Meta, c.InternalMeta} {
+		for key, value := range meta {
+			meta[key] = deepCopy(value)
+		}
+	}
+	return c
+}
+
+// deepCopy returns v with its maps and slices, including nested ones,
+// copied.
+func deepCopy(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, string, bool, int, int64, float64:
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	if k := rv.Kind(); k != reflect.Map && k != reflect.Slice {
+		return v
+	}
+	return deepCopyValue(rv, 0).Interface()
+}
+
+// deepCopyValue returns a copy of the maps and slices of v, sharing the
+// values nested deeper than maxCopyDepth.
+func deepCopyValue(v reflect.Value, depth int) reflect.Value {
+	if depth >= maxCopyDepth {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		return deepCopyValue(v.Elem(), depth)
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopyValue(iter.Value(), depth+1))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i), depth+1))
+		}
+		return c
+	}
+	return v
+}
+
+// With returns a copy of the error with the given setters applied, leaving
+// the error unchanged, so predeclared errors can be used as templates shared
+// across goroutines and requests. The stack of the copy, if captured, is the
+// one of the caller of With.
+//
+//	var errNoAccount = errors.NotFound("no account", errors.SetMeta(errors.Meta{"service": "accounts"}))
+//	...
+//	return errNoAccount.With(errors.SetMeta(errors.Meta{"account": id}))
+func (e *Error) With(setters ...errorParamsSetter) *Error {
+	c := e.Clone()
+	if c.sampleStack(c.cfg()) {
+		c.stack = callers(1)
+	}
+	for _, fn := range setters {
+		fn(c)
+	}
+	c.breakCycle()
+	c.truncateMessage()
+	return c
+}
+
+// WithMessage is like With but also replaces the Message of the copy.
+//
+//	return errNoAccount.WithMessage(fmt.Sprintf("no account %s", id))
+func (e *Error) WithMessage(msg string, setters ...errorParamsSetter) *Error {
+	c := e.Clone()
+	if c.sampleStack(c.cfg()) {
+		c.stack = callers(1)
+	}
+	c.Message = msg
+	for _, fn := range setters {
+		fn(c)
+	}
+	c.breakCycle()
+	c.truncateMessage()
+	return c
+}
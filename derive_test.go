@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	template := NotFound("no account", SetMeta(Meta{"service": "accounts"}), SetInternalMeta(Meta{"table": "accounts"}))
+
+	var wg sync.WaitGroup
+	derived := make([]*Error, 8)
+	for i := range derived {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			derived[i] = template.With(SetMeta(Meta{"account": i}), SetInternalMeta(Meta{"query": i}))
+		}(i)
+	}
+	wg.Wait()
+
+	if exp := (Meta{"service": "accounts"}); !reflect.DeepEqual(template.Meta, exp) {
+		t.Errorf("With() modified the template meta\n exp: %v\n got: %v\n", exp, template.Meta)
+	}
+	if exp := (Meta{"table": "accounts"}); !reflect.DeepEqual(template.InternalMeta, exp) {
+		t.Errorf("With() modified the template internal meta\n exp: %v\n got: %v\n", exp, template.InternalMeta)
+	}
+	for i, e := range derived {
+		if exp := (Meta{"service": "accounts", "account": i}); !reflect.DeepEqual(e.Meta, exp) {
+			t.Errorf("With()\n exp: %v\n got: %v\n", exp, e.Meta)
+		}
+	}
+
+	e := template.WithMessage(fmt.Sprintf("no account %d", 1))
+	if e.Message != "no account 1" || template.Message != "no account" {
+		t.Errorf("WithMessage()\n exp: %q (template %q)\n got: %q (template %q)\n", "no account 1", "no account", e.Message, template.Message)
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	CaptureStacks(true)
+	defer CaptureStacks(false)
+
+	template := newTemplate()
+	if got := fmt.Sprintf("%n", template.With().StackTrace()[0]); got != "TestWithStack" {
+		t.Errorf("With() stack\n exp: %s\n got: %s\n", "TestWithStack", got)
+	}
+}
+
+func newTemplate() *Error {
+	return NotFound("no account")
+}
+
+func TestCloneDeep(t *testing.T) {
+	nested := NotFound("no bank")
+	e := BadRequest("invalid transfer", SetMeta(Meta{
+		"accounts": []string{"a1", "a2"},
+		"limits":   map[string]interface{}{"daily": []interface{}{1, 2}},
+		"bank":     nested,
+	}))
+
+	c := e.Clone()
+	c.Meta["accounts"].([]string)[0] = "a3"
+	limits := c.Meta["limits"].(map[string]interface{})
+	limits["monthly"] = 3
+	limits["daily"].([]interface{})[0] = 0
+
+	exp := Meta{
+		"accounts": []string{"a1", "a2"},
+		"limits":   map[string]interface{}{"daily": []interface{}{1, 2}},
+		"bank":     nested,
+	}
+	if !reflect.DeepEqual(e.Meta, exp) {
+		t.Errorf("Clone() shares nested meta values\n exp: %v\n got: %v\n", exp, e.Meta)
+	}
+	if c.Meta["bank"] != nested {
+		t.Errorf("Clone() copied a nested error")
+	}
+
+	cyclic := map[string]interface{}{}
+	cyclic["self"] = cyclic
+	_ = NotFound("", SetMeta(Meta{"cyclic": cyclic})).Clone()
+}
@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// dlqVersion is the version of the DLQPayload format.
+const dlqVersion = 1
+
+// DLQPayload is the dead letter of a background job that exhausted its
+// attempts, written by the queue consumers and parsed by the replay tooling.
+// Its json form always carries the internal error, its cause chain and the
+// InternalMeta, whatever the Mode, after applying the registered scrubbers.
+type DLQPayload struct {
+	MessageID     string    // id of the original message in its queue
+	Queue         string    // queue of the original message
+	Error         *Error    // error of the last attempt
+	Attempts      int       // attempts made
+	FirstFailedAt time.Time // time of the first failed attempt
+	LastFailedAt  time.Time // time of the last failed attempt
+}
+
+// NewDLQPayload returns the DLQPayload of the message that failed with err,
+// with the attempts of its JobRetry, see SetAttempt, and failed last now. A
+// nil err is recorded as an internal_server "nil error" error.
+func NewDLQPayload(queue, messageID string, err error, firstFailedAt time.Time) DLQPayload {
+	e := BuildError(err)
+	if e == nil {
+		e = InternalServer("nil error")
+	}
+	p := DLQPayload{
+		MessageID:     messageID,
+		Queue:         queue,
+		Error:         e,
+		Attempts:      1,
+		FirstFailedAt: firstFailedAt,
+		LastFailedAt:  now(),
+	}
+	if r, ok := e.JobRetry(); ok {
+		p.Attempts = r.Attempt
+	}
+	return p
+}
+
+type dlqJSON struct {
+	Version       int       `json:"version"`
+	MessageID     string    `json:"message_id"`
+	Queue         string    `json:"queue,omitempty"`
+	Error         *dlqError `json:"error"`
+	Attempts      int       `json:"attempts"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastFailedAt  time.Time `json:"last_failed_at"`
+}
+
+type dlqError struct {
+	StatusCode   Code        `json:"status_code"`
+	ID           string      `json:"error_id"`
+	Message      string      `json:"msg,omitempty"`
+	UserMessage  string      `json:"user_msg,omitempty"`
+	Meta         Meta        `json:"meta,omitempty"`
+	InternalMeta Meta        `json:"internal_meta,omitempty"`
+	Causes       []wireCause `json:"causes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p DLQPayload) MarshalJSON() ([]byte, error) {
+	obj := dlqJSON{
+		Version:       dlqVersion,
+		MessageID:     p.MessageID,
+		Queue:         p.Queue,
+		Attempts:      p.Attempts,
+		FirstFailedAt: p.FirstFailedAt.UTC(),
+		LastFailedAt:  p.LastFailedAt.UTC(),
+	}
+	if p.Error != nil {
+		e := scrub(p.Error)
+		obj.Error = &dlqError{
+			StatusCode:   e.StatusCode,
+			ID:           e.ErrorID(),
+			Message:      e.Message,
+			UserMessage:  e.UserMessage,
+			Meta:         e.Meta,
+			InternalMeta: e.InternalMeta,
+			Causes:       encodeCauses(e.InternalError),
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The cause chain of the error is
+// rebuilt as FromGRPC does.
+func (p *DLQPayload) UnmarshalJSON(b []byte) error {
+	var obj dlqJSON
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+	if obj.Version > dlqVersion {
+		return fmt.Errorf("errors: unsupported dead letter version %d", obj.Version)
+	}
+
+	*p = DLQPayload{
+		MessageID:     obj.MessageID,
+		Queue:         obj.Queue,
+		Attempts:      obj.Attempts,
+		FirstFailedAt: obj.FirstFailedAt,
+		LastFailedAt:  obj.LastFailedAt,
+	}
+	if obj.Error != nil {
+		p.Error = &Error{
+			StatusCode:    obj.Error.StatusCode,
+			Message:       intern(obj.Error.Message),
+			UserMessage:   intern(obj.Error.UserMessage),
+			Meta:          obj.Error.Meta,
+			InternalMeta:  obj.Error.InternalMeta,
+			InternalError: decodeCauses(obj.Error.Causes),
+		}
+	}
+	return nil
+}
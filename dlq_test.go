@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDLQPayload(t *testing.T) {
+	current := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	cause := fmt.Errorf("get account: %w", NotFoundFromError(stderrors.New("sql: no rows"), "no account", SetMeta(Meta{"account": "a1"})))
+	err := InternalServerFromError(cause, "sync failed",
+		SetAttempt(5, RetryPolicy{MaxAttempts: 5}),
+		SetInternalMeta(Meta{"bank": "bci"}),
+	)
+
+	p := NewDLQPayload("transfers", "m1", err, current.Add(-time.Hour))
+	b, marshalErr := json.Marshal(p)
+	if marshalErr != nil {
+		t.Fatalf("json.Marshal(%+v) unexpected error: %v", p, marshalErr)
+	}
+
+	var got DLQPayload
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) unexpected error: %v", b, err)
+	}
+
+	if got.MessageID != "m1" || got.Queue != "transfers" || got.Attempts != 5 ||
+		!got.FirstFailedAt.Equal(current.Add(-time.Hour)) || !got.LastFailedAt.Equal(current) {
+		t.Errorf("json.Unmarshal(%s)\n exp: %+v\n got: %+v\n", b, p, got)
+	}
+	if got.Error.StatusCode != StatusInternalServerError || got.Error.Message != "sync failed" || got.Error.InternalMeta["bank"] != "bci" {
+		t.Errorf("DLQPayload error\n exp: %v\n got: %v\n", err, got.Error)
+	}
+
+	var notFound *Error
+	if !stderrors.As(got.Error.InternalError, &notFound) || notFound.Message != "no account" || !reflect.DeepEqual(notFound.Meta, Meta{"account": "a1"}) {
+		t.Errorf("DLQPayload cause chain\n exp: %v\n got: %v\n", cause, got.Error.InternalError)
+	}
+	if desc := got.Error.desc(); desc != err.desc() {
+		t.Errorf("DLQPayload desc\n exp: %q\n got: %q\n", err.desc(), desc)
+	}
+
+	if p := NewDLQPayload("transfers", "m2", nil, current); p.Error == nil || p.Error.StatusCode != StatusInternalServerError || p.Error.Message != "nil error" {
+		t.Errorf("NewDLQPayload(nil)\n exp: internal_server \"nil error\"\n got: %v\n", p.Error)
+	}
+
+	if err := json.Unmarshal([]byte(`{"version":2}`), &got); err == nil {
+		t.Errorf("json.Unmarshal() expected error for an unsupported version")
+	}
+}
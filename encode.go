@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity from which encoding buffers are not put
+// back in the pool, so a huge error does not pin its memory.
+const maxPooledBuffer = 64 << 10
+
+// encoder is a json encoder writing to its own buffer.
+type encoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var encoders = sync.Pool{
+	New: func() interface{} {
+		e := &encoder{}
+		e.enc = json.NewEncoder(&e.buf)
+		return e
+	},
+}
+
+// getEncoder returns an encoder from the pool, to be given back with
+// putEncoder.
+func getEncoder() *encoder {
+	e := encoders.Get().(*encoder)
+	e.buf.Reset()
+	return e
+}
+
+// putEncoder puts e back in the pool. The bytes returned by its encode
+// method must not be used afterwards.
+func putEncoder(e *encoder) {
+	if e.buf.Cap() > maxPooledBuffer {
+		return
+	}
+	encoders.Put(e)
+}
+
+// encode returns the json encoding of v, like json.Marshal. The returned
+// bytes are only valid until the next use of the encoder.
+func (e *encoder) encode(v interface{}) ([]byte, error) {
+	e.buf.Reset()
+	if err := e.enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(e.buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// SetDomain sets the domain of the errdetails.ErrorInfo attached to grpc
+// statuses, "finciero.com" by default.
+func SetDomain(d string) {
+	UpdateConfig(func(c *Config) { c.Domain = d })
+}
+
+// withErrorDetails returns s with the standard google.rpc error details of
+// the error, so clients of other languages understand it natively: an
+// ErrorInfo with the error id as reason and the Meta as metadata, a
+// RetryInfo if it has RetryAfter, a QuotaFailure with its Quota, a
+// BadRequest with its field errors, a RequestInfo with its request id, a
+// DebugInfo in Debug mode and a LocalizedMessage with its UserMessage.
+func (e *Error) withErrorDetails(s *status.Status) *status.Status {
+	metadata := make(map[string]string, len(e.Meta))
+	for key, value := range e.Meta {
+		if str, ok := value.(string); ok {
+			metadata[key] = str
+			continue
+		}
+		metadata[key] = fmt.Sprint(metaValue(value))
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(e.ErrorID()),
+		Domain:   e.cfg().Domain,
+		Metadata: metadata,
+	}}
+	if d, ok := e.RetryAfter(); ok {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(d)})
+	}
+	if failure := e.quotaFailure(); failure != nil {
+		details = append(details, failure)
+	}
+	if violations := e.fieldViolations(); violations != nil {
+		details = append(details, violations)
+	}
+	if id, ok := e.Meta[RequestIDKey]; ok {
+		info := &errdetails.RequestInfo{RequestId: fmt.Sprint(id)}
+		if data, ok := e.Meta[ServingDataKey]; ok {
+			info.ServingData = fmt.Sprint(data)
+		}
+		details = append(details, info)
+	}
+	if debug := e.debugInfo(); debug != nil {
+		details = append(details, debug)
+	}
+	if len(e.UserMessage) > 0 {
+		locale := e.locale
+		if len(locale) == 0 {
+			locale = DefaultLanguage.String()
+		}
+		details = append(details, &errdetails.LocalizedMessage{Locale: locale, Message: e.UserMessage})
+	}
+
+	return withDetails(s, details...)
+}
+
+// withDetails returns s with the given details, or s itself if they can not
+// be attached.
+func withDetails(s *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	if sd, err := s.WithDetails(details...); err == nil {
+		return sd
+	}
+	return s
+}
+
+// fromErrorDetails returns an Error from the standard google.rpc error
+// details of s, for statuses not encoded by this package. It reports false
+// if s has no ErrorInfo, BadRequest or QuotaFailure.
+func fromErrorDetails(s *status.Status) (*Error, bool) {
+	code, ok := detailCode(s)
+	if !ok {
+		code = codeFromGRPC(s.Code())
+	}
+	e := &Error{StatusCode: code, Message: s.Message()}
+
+	var recognized bool
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			for key, value := range d.Metadata {
+				SetMetaNoCopy(Meta{key: value})(e)
+			}
+			recognized = true
+		case *errdetails.RetryInfo:
+			if d.RetryDelay != nil {
+				SetRetryAfter(d.RetryDelay.AsDuration())(e)
+			}
+		case *errdetails.BadRequest, *errdetails.QuotaFailure:
+			recognized = true
+		}
+	}
+	if !recognized {
+		return nil, false
+	}
+
+	e.readDetails(s)
+	return e, true
+}
+
+// readDetails sets into e the information carried in the details of s that
+// is not part of the encoded error: the UserMessage, the quota scope, the
+// request id and serving data, the debug information and the field errors.
+func (e *Error) readDetails(s *status.Status) {
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.LocalizedMessage:
+			if len(e.UserMessage) == 0 {
+				e.UserMessage, e.locale = intern(d.Message), d.Locale
+			}
+		case *errdetails.QuotaFailure:
+			if _, ok := e.Meta[ScopeKey]; !ok && len(d.Violations) > 0 && len(d.Violations[0].Subject) > 0 {
+				SetMetaNoCopy(Meta{ScopeKey: d.Violations[0].Subject})(e)
+			}
+		case *errdetails.DebugInfo:
+			if e.InternalError == nil && len(d.Detail) > 0 {
+				e.InternalError = errors.New(d.Detail)
+			}
+			if _, ok := e.InternalMeta[StackKey]; !ok && len(d.StackEntries) > 0 {
+				SetInternalMeta(Meta{StackKey: strings.Join(d.StackEntries, "\n")})(e)
+			}
+		case *errdetails.RequestInfo:
+			if _, ok := e.Meta[RequestIDKey]; !ok && len(d.RequestId) > 0 {
+				SetMetaNoCopy(Meta{RequestIDKey: d.RequestId})(e)
+			}
+			if _, ok := e.Meta[ServingDataKey]; !ok && len(d.ServingData) > 0 {
+				SetMetaNoCopy(Meta{ServingDataKey: d.ServingData})(e)
+			}
+		}
+	}
+	e.setFieldErrors(s)
+}
+
+// debugInfo returns the errdetails.DebugInfo of the error with its stack
+// and internal error in Debug mode, nil otherwise or if it has neither.
+func (e *Error) debugInfo() *errdetails.DebugInfo {
+	if e.cfg().Mode != Debug {
+		return nil
+	}
+
+	debug := &errdetails.DebugInfo{StackEntries: e.stackEntries()}
+	if e.InternalError != nil {
+		debug.Detail = e.desc()
+	}
+
+	if len(debug.StackEntries) == 0 && len(debug.Detail) == 0 {
+		return nil
+	}
+	return debug
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestErrorDetails(t *testing.T) {
+	e := RateLimit("slow down", SetMeta(Meta{"user": "u1", "attempts": 3}), SetRetryAfter(1500*time.Millisecond))
+
+	var (
+		info  *errdetails.ErrorInfo
+		retry *errdetails.RetryInfo
+	)
+	for _, detail := range e.GRPCStatus().Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.RetryInfo:
+			retry = d
+		}
+	}
+
+	exp := map[string]string{"user": "u1", "attempts": "3", RetryAfterKey: "1.5s"}
+	if info == nil || info.Reason != "RATE_LIMIT" || info.Domain != "finciero.com" || !reflect.DeepEqual(info.Metadata, exp) {
+		t.Errorf("GRPCStatus() ErrorInfo\n exp: RATE_LIMIT finciero.com %v\n got: %v\n", exp, info)
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() != 1500*time.Millisecond {
+		t.Errorf("GRPCStatus() RetryInfo\n exp: 1.5s\n got: %v\n", retry)
+	}
+
+	foreign, _ := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(
+		&errdetails.ErrorInfo{Reason: "RATE_LIMIT_EXCEEDED", Domain: "googleapis.com", Metadata: map[string]string{"service": "maps"}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)},
+	)
+
+	got := FromGRPC(foreign.Err())
+	if d, _ := got.RetryAfter(); got.StatusCode != StatusTooManyRequests || got.Message != "quota exceeded" || got.Meta["service"] != "maps" || d != time.Second {
+		t.Errorf("FromGRPC(foreign)\n exp: quota exceeded service=maps retry_after=1s\n got: %v\n", got)
+	}
+}
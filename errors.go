@@ -3,10 +3,13 @@ package errors
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Code type
@@ -14,16 +17,19 @@ type Code int32
 
 // Codes identifiers
 const (
-	bad_request    Code = 400
-	unauthorized   Code = 401
-	delinquent     Code = 402
-	forbidden      Code = 403
-	not_found      Code = 404
-	not_acceptable Code = 406
-	invalid_params Code = 422
-	rate_limit     Code = 429
-
-	internal_server Code = 500
+	bad_request        Code = 400
+	unauthorized       Code = 401
+	delinquent         Code = 402
+	forbidden          Code = 403
+	not_found          Code = 404
+	method_not_allowed Code = 405
+	not_acceptable     Code = 406
+	request_timeout    Code = 408
+	invalid_params     Code = 422
+	rate_limit         Code = 429
+
+	client_closed_request Code = 499
+	internal_server       Code = 500
 )
 
 // Exportable aliases from real codes
@@ -33,10 +39,13 @@ const (
 	StatusPaymentRequired     = delinquent
 	StatusForbidden           = forbidden
 	StatusNotFound            = not_found
+	StatusMethodNotAllowed    = method_not_allowed
 	StatusNotAcceptable       = not_acceptable
+	StatusRequestTimeout      = request_timeout
 	StatusUnprocessableEntity = invalid_params
 	StatusTooManyRequests     = rate_limit
 
+	StatusClientClosedRequest = client_closed_request
 	StatusInternalServerError = internal_server
 )
 
@@ -51,7 +60,28 @@ type Error struct {
 	Meta       Meta
 	Message    string
 
+	UserMessage string // localized message that can be shown to end users
+
+	// FallbackAllowed tells callers they may serve stale or deferred data
+	// instead of failing, e.g. a degraded dependency.
+	FallbackAllowed bool
+
+	// Priority tells how urgently the error needs attention, empty for the
+	// default of its code, see PriorityOf. It is sent through grpc, so the
+	// sinks of the callers see it, but not in JSON.
+	Priority Priority
+
 	InternalError error // internal information used for debugging
+	InternalMeta  Meta  // internal metadata used for debugging
+
+	locale    string    // language of UserMessage, set by Localize
+	stack     []uintptr // program counters captured on construction
+	metaOrder []string  // insertion order of the keys set by SetOrderedMeta
+	expiresAt time.Time // end of the time the error may be cached, see SetTTL
+	config    *Config   // configuration set by SetContextConfig
+
+	suppressed []error // secondary failures, see Combine
+	pooled     bool    // acquired with Acquire, copied before being kept
 }
 
 // Meta stores metadata that can be visible for end users and developers
@@ -59,73 +89,223 @@ type Meta map[string]interface{}
 
 // New returns a new Error
 func New(code Code, msg string, setters ...errorParamsSetter) *Error {
-	var meta Meta
-	for _, fn := range setters {
-		fn(&meta)
-	}
-	return &Error{
-		StatusCode: code,
-		Meta:       meta,
-		Message:    msg,
-	}
+	return newError(code, nil, msg, setters)
 }
 
 // NewFromError returns a New Error with description of the error given
 func NewFromError(code Code, err error, msg string, setters ...errorParamsSetter) *Error {
-	var meta Meta
-	for _, fn := range setters {
-		fn(&meta)
-	}
-	return &Error{
+	return newError(code, err, msg, setters)
+}
+
+// newError returns a new Error. It must be called directly by the exported
+// constructors, so the captured stack starts at their caller.
+func newError(code Code, err error, msg string, setters []errorParamsSetter) *Error {
+	e := &Error{
 		StatusCode: code,
-		Meta:       meta,
 		Message:    msg,
 
 		InternalError: err,
 	}
+	if e.sampleStack(loadConfig()) {
+		e.stack = callers(2)
+	}
+	for _, fn := range setters {
+		fn(e)
+	}
+	e.applyCodeDefaults()
+	e.breakCycle()
+	e.truncateMessage()
+	return e
 }
 
 // FromGRPC returns a new Error from an error received by grpc. If the
 // error was encoded with ToGPC method then the full Error passed is
-// returned.
+// returned. It returns nil if err is nil, and errors that are not grpc
+// errors are converted with BuildError. Descriptions that can not be parsed
+// are returned as internal_server errors with the raw description and code
+// in InternalMeta, see RawDescKey.
 func FromGRPC(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return BuildError(err)
+	}
+	return FromStatus(s)
+}
+
+// InternalMeta keys of the errors whose grpc description could not be
+// parsed.
+const (
+	RawDescKey = "raw_desc" // description as received
+	RawCodeKey = "raw_code" // grpc code as received
+)
+
+// FromStatus is like FromGRPC but takes the grpc status, e.g. one already
+// held by gateway code or other interceptors. It returns nil if s is nil or
+// OK.
+func FromStatus(s *status.Status) *Error {
+	if s == nil || s.Code() == codes.OK {
+		return nil
+	}
+
 	var raw struct {
-		Meta          Meta   `json:"meta, omitempty"`
-		Message       string `json:"msg, omitempty"`
-		InternalError error  `json:"internal_error,omitempty"`
+		Meta          Meta        `json:"meta, omitempty"`
+		Message       string      `json:"msg, omitempty"`
+		InternalError string      `json:"internal_error,omitempty"`
+		InternalMeta  Meta        `json:"internal_meta,omitempty"`
+		Causes        []wireCause `json:"causes,omitempty"`
+		MetaOrder     []string    `json:"meta_order,omitempty"`
+		Fallback      bool        `json:"fallback_allowed,omitempty"`
+		Suppressed    []*Error    `json:"suppressed,omitempty"`
+		Priority      Priority    `json:"priority,omitempty"`
 	}
 
-	code := grpc.Code(err)
-	desc := grpc.ErrorDesc(err)
+	code, ok := detailCode(s)
+	if !ok {
+		code = Code(s.Code())
+	}
+	desc := s.Message()
+	if payload, ok := v2Payload(s); ok {
+		desc = payload
+	}
+	desc = unquote(desc)
 
 	if unmarshalError := json.Unmarshal([]byte(desc), &raw); unmarshalError != nil {
-		return InternalServerFromError(err, "unexpected error")
+		if e, ok := fromErrorDetails(s); ok {
+			return e
+		}
+		// foreign statuses, e.g. "transport is closing", are not failures
+		if strings.HasPrefix(strings.TrimSpace(desc), "{") {
+			decodeFailed([]byte(desc), unmarshalError)
+		}
+		return InternalServerFromError(s.Err(), "unexpected error", SetInternalMeta(Meta{
+			RawDescKey: desc,
+			RawCodeKey: int(s.Code()),
+		}))
 	}
 
-	return &Error{
-		StatusCode: Code(code),
+	e := &Error{
+		StatusCode: code,
 		Meta:       raw.Meta,
-		Message:    raw.Message,
+		Message:    intern(raw.Message),
+
+		InternalMeta: raw.InternalMeta,
+
+		FallbackAllowed: raw.Fallback,
+		Priority:        raw.Priority,
+
+		metaOrder: raw.MetaOrder,
+	}
+	if len(raw.Causes) > 0 {
+		e.InternalError = decodeCauses(raw.Causes)
+	} else if len(raw.InternalError) > 0 {
+		e.InternalError = errors.New(raw.InternalError)
+	}
+	e.setSuppressed(raw.Suppressed)
+	e.readDetails(s)
+
+	return e
+}
 
-		InternalError: raw.InternalError,
+// unquote returns the json payload double encoded as a json string by legacy
+// services, or desc unchanged.
+func unquote(desc string) string {
+	if len(desc) < 2 || desc[0] != '"' {
+		return desc
 	}
+	var payload string
+	if err := json.Unmarshal([]byte(desc), &payload); err != nil || !strings.HasPrefix(payload, "{") {
+		return desc
+	}
+	return payload
 }
 
-// ToGRPC ecode error into a grpc error
+// ToGRPC ecode error into a grpc error. The internal error, its cause chain
+// and the internal meta are only included in Debug mode. The registered
+// scrubbers are applied first.
 func (e *Error) ToGRPC() error {
-	buff, _ := json.Marshal(struct {
-		Meta    Meta   `json:"meta,omitempty"`
-		Message string `json:"msg,omitempty"`
+	return e.GRPCStatus().Err()
+}
+
+// ToStatus returns the grpc status encoded by ToGRPC.
+func (e *Error) ToStatus() *status.Status {
+	return e.GRPCStatus()
+}
 
-		InternalError error `json:"internal_error,omitempty"`
-	}{
-		Meta:    e.Meta,
-		Message: e.Message,
+// ToGRPCE is like ToGRPC but also returns the error found encoding the
+// error, in which case the grpc error only carries its code and message.
+func (e *Error) ToGRPCE() (error, error) {
+	s, err := e.grpcStatus(e.cfg().WireFormat)
+	return s.Err(), err
+}
+
+// GRPCStatus returns the grpc status encoded by ToGRPC. It allows the
+// status package, and so grpc servers, to encode an *Error returned as is.
+func (e *Error) GRPCStatus() *status.Status {
+	s, _ := e.grpcStatus(e.cfg().WireFormat)
+	return s
+}
 
-		InternalError: e.InternalError,
-	})
+// grpcStatus returns the grpc status of the error in the given wire format.
+// If the error can not be encoded, e.g. a Meta value is not serializable,
+// the failure is recorded and the status only carries the code and message.
+func (e *Error) grpcStatus(f WireFormat) (*status.Status, error) {
+	e = scrub(e)
+	internalError, internalMeta := e.internal()
 
-	return grpc.Errorf(codes.Code(e.StatusCode), string(buff))
+	var causes []wireCause
+	if e.cfg().Mode == Debug {
+		causes = encodeCauses(e.InternalError)
+	}
+
+	meta, err := e.marshalMeta()
+
+	enc := getEncoder()
+	defer putEncoder(enc)
+
+	var buff []byte
+	if err == nil {
+		buff, err = enc.encode(struct {
+			Meta    json.RawMessage `json:"meta,omitempty"`
+			Message string          `json:"msg,omitempty"`
+
+			InternalError string      `json:"internal_error,omitempty"`
+			InternalMeta  Meta        `json:"internal_meta,omitempty"`
+			Causes        []wireCause `json:"causes,omitempty"`
+			MetaOrder     []string    `json:"meta_order,omitempty"`
+			Fallback      bool        `json:"fallback_allowed,omitempty"`
+			Suppressed    []*Error    `json:"suppressed,omitempty"`
+			Priority      Priority    `json:"priority,omitempty"`
+		}{
+			Meta:    meta,
+			Message: e.Message,
+
+			InternalError: internalError,
+			InternalMeta:  internalMeta,
+			Causes:        causes,
+			MetaOrder:     e.metaOrder,
+			Fallback:      e.FallbackAllowed,
+			Suppressed:    e.suppressedErrors(),
+			Priority:      e.Priority,
+		})
+	}
+	if err != nil {
+		encodeFailed(e, err)
+		buff, _ = json.Marshal(struct {
+			Message string `json:"msg,omitempty"`
+		}{e.Message})
+	}
+
+	s := status.New(e.StatusCode.grpcCode(e.cfg()), string(buff))
+	if f == WireV2 {
+		s = e.v2Status(buff)
+	}
+	return withCodeDetail(e.withErrorDetails(s), e.StatusCode, e.cfg()), err
 }
 
 // Code returns error StatusCode casted to int
@@ -135,149 +315,308 @@ func (e *Error) Code() int {
 
 // Error method return string representation of error.
 func (e *Error) Error() string {
-	str := fmt.Sprintf("status_code=%d error_id=%q", e.StatusCode, fmt.Sprint(e.StatusCode))
+	str := fmt.Sprintf("status_code=%d error_id=%q", e.StatusCode, e.ErrorID())
 
 	if len(e.Message) > 0 {
 		str += fmt.Sprintf(" msg=%q", e.Message)
 	}
 
 	if e.InternalError != nil {
-		str += fmt.Sprintf(" desc=%q", e.InternalError.Error())
+		str += fmt.Sprintf(" desc=%q", e.desc())
 	}
 
-	for key, value := range e.Meta {
-		str += fmt.Sprintf(" %s=%q", key, value)
+	for _, key := range e.MetaKeys() {
+		str += fmt.Sprintf(" %s=%q", key, e.Meta[key])
 	}
 
 	return str
 }
 
-// ErrorID returns string representation of the error StatusCode.
+// ErrorID returns string representation of the error StatusCode, or its
+// registered id for custom codes.
 func (e *Error) ErrorID() string {
-	return fmt.Sprint(e.StatusCode)
+	return e.StatusCode.id()
 }
 
-type errorParamsSetter func(*Meta)
+type errorParamsSetter func(*Error)
 
-// SetMeta sets the given key values into the Meta of the error.
+// SetMeta sets the given key values into the Meta of the error. m is
+// copied, so it may be shared, e.g. a template map, and modified afterwards
+// without affecting the error, and the error never modifies it.
 func SetMeta(m Meta) errorParamsSetter {
-	return func(params *Meta) {
-		if (*params) == nil {
-			(*params) = m
+	return func(e *Error) {
+		if e.Meta == nil {
+			if m == nil {
+				return
+			}
+			e.Meta = make(Meta, len(m))
+		}
+
+		for key, value := range m {
+			e.Meta[key] = value
+		}
+	}
+}
+
+// SetMetaNoCopy is like SetMeta but adopts m as is as the Meta of an error
+// without one, instead of copying it, for hot paths that build a fresh map
+// anyway. The caller must not use m afterwards: the error owns it and may
+// add keys to it.
+//
+//	errors.NotFound("no account", errors.SetMetaNoCopy(errors.Meta{"account": id}))
+func SetMetaNoCopy(m Meta) errorParamsSetter {
+	return func(e *Error) {
+		if e.Meta == nil {
+			e.Meta = m
 			return
 		}
 
 		for key, value := range m {
-			(*params)[key] = value
+			e.Meta[key] = value
+		}
+	}
+}
+
+// SetInternalMeta sets the given key values into the InternalMeta of the
+// error.
+func SetInternalMeta(m Meta) errorParamsSetter {
+	return func(e *Error) {
+		if e.InternalMeta == nil {
+			e.InternalMeta = Meta{}
+		}
+
+		for key, value := range m {
+			e.InternalMeta[key] = value
 		}
 	}
 }
 
 // BadRequest returns an Error with bad_request code
 func BadRequest(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusBadRequest, message, setters...)
+	return newError(StatusBadRequest, nil, message, setters)
 }
 
 // BadRequestFromError returns an Error with bad_request code with err as a
 // internalError.
 func BadRequestFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusBadRequest, err, msg, setters...)
+	return newError(StatusBadRequest, err, msg, setters)
 }
 
 // Unauthorized returns an Error with unauthorized code
 func Unauthorized(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusUnauthorized, message, setters...)
+	return newError(StatusUnauthorized, nil, message, setters)
 }
 
 // UnauthorizedFromError returns an Error with unauthorized code with err as a
 // internalError.
 func UnauthorizedFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusUnauthorized, err, msg, setters...)
+	return newError(StatusUnauthorized, err, msg, setters)
 }
 
 // Delinquent returns an Error with delinquent code
 func Delinquent(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusPaymentRequired, message, setters...)
+	return newError(StatusPaymentRequired, nil, message, setters)
 }
 
 // DelinquentFromError returns an Error with delinquent code with err as a
 // internalError.
 func DelinquentFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusPaymentRequired, err, msg, setters...)
+	return newError(StatusPaymentRequired, err, msg, setters)
 }
 
 // Forbidden returns an Error with forbidden code
 func Forbidden(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusForbidden, message, setters...)
+	return newError(StatusForbidden, nil, message, setters)
 }
 
 // ForbiddenFromError returns an Error with forbidden code with err as a
 // internalError.
 func ForbiddenFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusForbidden, err, msg, setters...)
+	return newError(StatusForbidden, err, msg, setters)
 }
 
 // NotFound returns an Error with not_found code
 func NotFound(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusNotFound, message, setters...)
+	return newError(StatusNotFound, nil, message, setters)
 }
 
 // NotFoundFromError returns an Error with not_found code with err as a
 // internalError.
 func NotFoundFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusNotFound, err, msg, setters...)
+	return newError(StatusNotFound, err, msg, setters)
+}
+
+// MethodNotAllowed returns an Error with method_not_allowed code. The http
+// writers set the Allow header from the AllowKey Meta, see SetAllow.
+func MethodNotAllowed(message string, setters ...errorParamsSetter) *Error {
+	return newError(StatusMethodNotAllowed, nil, message, setters)
+}
+
+// MethodNotAllowedFromError returns an Error with method_not_allowed code
+// with err as a internalError.
+func MethodNotAllowedFromError(err error, msg string, setters ...errorParamsSetter) *Error {
+	return newError(StatusMethodNotAllowed, err, msg, setters)
 }
 
 // NotAcceptable returns an Error with not_acceptable code
 func NotAcceptable(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusNotAcceptable, message, setters...)
+	return newError(StatusNotAcceptable, nil, message, setters)
 }
 
 // NotAcceptableFromError returns an Error with not_acceptable code with err as a
 // internalError.
 func NotAcceptableFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusNotAcceptable, err, msg, setters...)
+	return newError(StatusNotAcceptable, err, msg, setters)
+}
+
+// RequestTimeout returns an Error with request_timeout code
+func RequestTimeout(message string, setters ...errorParamsSetter) *Error {
+	return newError(StatusRequestTimeout, nil, message, setters)
+}
+
+// RequestTimeoutFromError returns an Error with request_timeout code with err
+// as a internalError.
+func RequestTimeoutFromError(err error, msg string, setters ...errorParamsSetter) *Error {
+	return newError(StatusRequestTimeout, err, msg, setters)
 }
 
 // InvalidParams returns an Error with invalid_params code
 func InvalidParams(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusUnprocessableEntity, message, setters...)
+	return newError(StatusUnprocessableEntity, nil, message, setters)
 }
 
 // InvalidParamsFromError returns an Error with invalid_params code with err as a
 // internalError.
 func InvalidParamsFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusUnprocessableEntity, err, msg, setters...)
+	return newError(StatusUnprocessableEntity, err, msg, setters)
 }
 
 // RateLimit returns an Error with rate_limit code
 func RateLimit(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusTooManyRequests, message, setters...)
+	return newError(StatusTooManyRequests, nil, message, setters)
 }
 
 // RateLimitFromError returns an Error with rate_limit code with err as a
 // internalError.
 func RateLimitFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusTooManyRequests, err, msg, setters...)
+	return newError(StatusTooManyRequests, err, msg, setters)
+}
+
+// ClientClosedRequest returns an Error with client_closed_request code
+func ClientClosedRequest(message string, setters ...errorParamsSetter) *Error {
+	return newError(StatusClientClosedRequest, nil, message, setters)
+}
+
+// ClientClosedRequestFromError returns an Error with client_closed_request
+// code with err as a internalError.
+func ClientClosedRequestFromError(err error, msg string, setters ...errorParamsSetter) *Error {
+	return newError(StatusClientClosedRequest, err, msg, setters)
 }
 
 // InternalServer returns an Error with internal_server code
 func InternalServer(message string, setters ...errorParamsSetter) *Error {
-	return New(StatusInternalServerError, message, setters...)
+	return newError(StatusInternalServerError, nil, message, setters)
 }
 
 // InternalServerFromError returns an Error with internal_server code with err as a
 // internalError.
 func InternalServerFromError(err error, msg string, setters ...errorParamsSetter) *Error {
-	return NewFromError(StatusInternalServerError, err, msg, setters...)
+	return newError(StatusInternalServerError, err, msg, setters)
 }
 
-// MarshalJSON serialize error to json
+// MarshalJSON serialize error to json. The internal error and meta, and the
+// stack captured on construction, are only included in Debug mode, while the
+// sub-errors of a composite error are always included in the errors array.
+// The registered scrubbers are applied first.
 func (e *Error) MarshalJSON() (b []byte, err error) {
-	return json.Marshal(struct {
-		Meta       Meta   `json:"meta,omitempty"`
-		Message    string `json:"msg,omitempty"`
-		ErrorID    string `json:"error_id"`
-		StatusCode Code   `json:"status_code"`
-	}{e.Meta, e.Message, fmt.Sprint(e.StatusCode), e.StatusCode})
+	e = scrub(e)
+	e = e.named(e.cfg().Naming)
+
+	meta, err := e.marshalMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	enc := getEncoder()
+	defer putEncoder(enc)
+
+	b, err = enc.encode(e.jsonObject(meta, false))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+// jsonObject returns the value serialized by MarshalJSON, with the given
+// meta json object. The nested errors and the InternalMeta are serialized
+// with MarshalCanonicalJSON if canonical is set.
+func (e *Error) jsonObject(meta json.RawMessage, canonical bool) interface{} {
+	internalError, internalMeta := e.internal()
+	if canonical {
+		internalMeta = canonicalMeta(internalMeta)
+	}
+
+	obj := jsonError{meta, e.Message, e.UserMessage, e.ErrorID(), e.StatusCode, e.FallbackAllowed, internalError, internalMeta, e.debugStack(),
+		marshalers(e.Errors(), canonical), marshalers(e.suppressedErrors(), canonical)}
+	if e.cfg().Naming == CamelCase {
+		return camelJSONError(obj)
+	}
+	return obj
+}
+
+// UnmarshalJSON deserialize error from json encoded with MarshalJSON.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Meta          Meta     `json:"meta,omitempty"`
+		Message       string   `json:"msg,omitempty"`
+		UserMessage   string   `json:"user_msg,omitempty"`
+		StatusCode    Code     `json:"status_code"`
+		Fallback      bool     `json:"fallback_allowed,omitempty"`
+		InternalError string   `json:"internal_error,omitempty"`
+		InternalMeta  Meta     `json:"internal_meta,omitempty"`
+		Errors        []*Error `json:"errors,omitempty"`
+		Suppressed    []*Error `json:"suppressed,omitempty"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw.StatusCode == 0 {
+		var camel struct {
+			Meta          Meta     `json:"meta,omitempty"`
+			Message       string   `json:"msg,omitempty"`
+			UserMessage   string   `json:"userMsg,omitempty"`
+			StatusCode    Code     `json:"statusCode"`
+			Fallback      bool     `json:"fallbackAllowed,omitempty"`
+			InternalError string   `json:"internalError,omitempty"`
+			InternalMeta  Meta     `json:"internalMeta,omitempty"`
+			Errors        []*Error `json:"errors,omitempty"`
+			Suppressed    []*Error `json:"suppressed,omitempty"`
+		}
+		if err := json.Unmarshal(b, &camel); err != nil {
+			return err
+		}
+		raw.Meta, raw.Message, raw.UserMessage = camel.Meta, camel.Message, camel.UserMessage
+		raw.StatusCode, raw.InternalError, raw.InternalMeta = camel.StatusCode, camel.InternalError, camel.InternalMeta
+		raw.Fallback, raw.Errors, raw.Suppressed = camel.Fallback, camel.Errors, camel.Suppressed
+	}
+
+	*e = Error{
+		StatusCode:  raw.StatusCode,
+		Meta:        raw.Meta,
+		Message:     intern(raw.Message),
+		UserMessage: intern(raw.UserMessage),
+
+		FallbackAllowed: raw.Fallback,
+
+		InternalMeta: raw.InternalMeta,
+	}
+	if len(raw.Errors) > 0 {
+		e.InternalError = joinErrors(raw.Errors)
+	} else if len(raw.InternalError) > 0 {
+		e.InternalError = errors.New(raw.InternalError)
+	}
+	e.setSuppressed(raw.Suppressed)
+
+	return nil
 }
@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNew(t *testing.T) {
@@ -124,6 +128,11 @@ func TestFromGRPC(t *testing.T) {
 				msg:  `{"msg":"let's go"}`,
 				exp:  Unauthorized("let's go"),
 			},
+			{
+				code: int(StatusBadRequest),
+				msg:  `"{\"meta\":{\"hi\":\"ho\"},\"msg\":\"let's go\"}"`,
+				exp:  BadRequest("let's go", SetMeta(Meta{"hi": "ho"})),
+			},
 		}
 
 		for _, tt := range tests {
@@ -397,3 +406,130 @@ func TestMarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestToGRPCEncodeFailure(t *testing.T) {
+	var hooked *Error
+	OnEncodeFailure(func(e *Error, err error) { hooked = e })
+	defer OnEncodeFailure(nil)
+
+	failures := EncodeFailures()
+	err := BadRequest("let's go", SetMeta(Meta{"fn": func() {}}))
+
+	got, encodeErr := err.ToGRPCE()
+	if encodeErr == nil {
+		t.Errorf("(%v).ToGRPCE() expected encode error", err)
+	}
+	if desc := grpc.ErrorDesc(got); grpc.Code(got) != codes.Code(StatusBadRequest) || desc != `{"msg":"let's go"}` {
+		t.Errorf("(%v).ToGRPCE()\n got: {code: %d, desc: %q}\n exp: {code: %d, desc: %q}\n",
+			err, grpc.Code(got), desc, StatusBadRequest, `{"msg":"let's go"}`)
+	}
+	if hooked != err || EncodeFailures() != failures+1 {
+		t.Errorf("(%v).ToGRPCE() failure not recorded", err)
+	}
+
+	if _, encodeErr := BadRequest("").ToGRPCE(); encodeErr != nil {
+		t.Errorf("ToGRPCE() unexpected encode error: %v", encodeErr)
+	}
+}
+
+func TestDecodeFailure(t *testing.T) {
+	var hooked []string
+	OnDecodeFailure(func(raw []byte, err error) { hooked = append(hooked, string(raw)) })
+	defer OnDecodeFailure(nil)
+
+	response := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusBadGateway, Body: ioutil.NopCloser(strings.NewReader(body))}
+	}
+
+	failures := DecodeFailures()
+	FromGRPC(status.Error(codes.Internal, `{"msg":"truncated`))
+	FromGRPC(status.Error(codes.Unavailable, "transport is closing"))
+	FromGRPC(BadRequest("valid").ToGRPC())
+	FromHTTPResponse(response(`{"error":"bad gateway"}`))
+	FromHTTPResponse(response(""))
+	FromHTTPResponse(response(strings.Repeat("x", 2*maxDecodeFailureData)))
+
+	exp := []string{`{"msg":"truncated`, `{"error":"bad gateway"}`, strings.Repeat("x", maxDecodeFailureData)}
+	if !reflect.DeepEqual(hooked, exp) {
+		t.Errorf("OnDecodeFailure()\n exp: %q\n got: %q\n", exp, hooked)
+	}
+	if got := DecodeFailures() - failures; got != 3 {
+		t.Errorf("DecodeFailures()\n exp: 3\n got: %d\n", got)
+	}
+}
+
+func TestFromGRPCEdgeCases(t *testing.T) {
+	var (
+		errTest  = errors.New("testing: test error")
+		notFound = NotFound("no account")
+	)
+
+	RegisterConverter(func(err error) (*Error, bool) {
+		if err == errTest {
+			return BadRequestFromError(err, "converted"), true
+		}
+		return nil, false
+	})
+	defer func() {
+		converters = nil
+	}()
+
+	tests := []struct {
+		err error
+		exp *Error
+	}{
+		{nil, nil},
+		{notFound, notFound},
+		{errTest, BadRequestFromError(errTest, "converted")},
+		{grpc.Errorf(codes.Unavailable, "not json"), InternalServerFromError(grpc.Errorf(codes.Unavailable, "not json"), "unexpected error",
+			SetInternalMeta(Meta{RawDescKey: "not json", RawCodeKey: int(codes.Unavailable)}))},
+	}
+
+	for _, tt := range tests {
+		err := FromGRPC(tt.err)
+
+		if !reflect.DeepEqual(err, tt.exp) {
+			t.Errorf("FromGRPC(%#v) = %#v\n\n exp: %v\n got: %v\n", tt.err, err, tt.exp, err)
+		}
+	}
+}
+
+func TestFromStatusToStatus(t *testing.T) {
+	e := NotFound("no account", SetMeta(Meta{"account": "a1"}))
+
+	tests := []struct {
+		s   *status.Status
+		exp *Error
+	}{
+		{nil, nil},
+		{status.New(codes.OK, ""), nil},
+		{e.ToStatus(), e},
+	}
+
+	for _, tt := range tests {
+		got := FromStatus(tt.s)
+		if (got == nil) != (tt.exp == nil) || (got != nil && !got.Equal(tt.exp)) {
+			t.Errorf("FromStatus(%v)\n exp: %v\n got: %v\n", tt.s, tt.exp, got)
+		}
+	}
+
+	if got := FromStatus(status.New(codes.Unavailable, "not json")); got.StatusCode != StatusInternalServerError {
+		t.Errorf("FromStatus(foreign)\n exp: %d\n got: %d\n", StatusInternalServerError, got.StatusCode)
+	}
+}
+
+func BenchmarkToGRPC(b *testing.B) {
+	b.ReportAllocs()
+	e := NotFound("no account", SetMeta(Meta{"account": "a1", "bank": "b1"}))
+	for i := 0; i < b.N; i++ {
+		_ = e.ToGRPC()
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	b.ReportAllocs()
+	e := NotFound("no account", SetMeta(Meta{"account": "a1", "bank": "b1"}))
+	for i := 0; i < b.N; i++ {
+		_, _ = e.MarshalJSON()
+	}
+}
@@ -0,0 +1,165 @@
+// Package errorstest provides fixtures of errors for tests, e.g. to fuzz
+// serializers or populate test databases without hand writing error
+// literals.
+package errorstest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+
+	"github.com/Finciero/errors"
+)
+
+// Builder builds an error fixture.
+//
+//	e := errorstest.Fixture(errors.StatusNotFound).
+//		Message("no account").
+//		Meta("account", "acc_1").
+//		Cause("sql: no rows in result set").
+//		Build()
+type Builder struct {
+	code         errors.Code
+	msg          string
+	meta         errors.Meta
+	internalMeta errors.Meta
+	causes       []string
+	stack        bool
+}
+
+// Fixture returns a Builder of an error with the given code and its
+// registered message.
+func Fixture(code errors.Code) *Builder {
+	return &Builder{code: code, msg: message(code)}
+}
+
+// Message sets the message of the error.
+func (b *Builder) Message(msg string) *Builder {
+	b.msg = msg
+	return b
+}
+
+// Meta sets a Meta value of the error.
+func (b *Builder) Meta(key string, value interface{}) *Builder {
+	if b.meta == nil {
+		b.meta = errors.Meta{}
+	}
+	b.meta[key] = value
+	return b
+}
+
+// InternalMeta sets an InternalMeta value of the error.
+func (b *Builder) InternalMeta(key string, value interface{}) *Builder {
+	if b.internalMeta == nil {
+		b.internalMeta = errors.Meta{}
+	}
+	b.internalMeta[key] = value
+	return b
+}
+
+// Cause adds an error with the given description to the cause chain of the
+// error, each one wrapping the previous ones.
+func (b *Builder) Cause(desc string) *Builder {
+	b.causes = append(b.causes, desc)
+	return b
+}
+
+// Stack sets the stack of the caller of Build into the InternalMeta of the
+// error under errors.StackKey.
+func (b *Builder) Stack() *Builder {
+	b.stack = true
+	return b
+}
+
+// Build returns the error.
+func (b *Builder) Build() *errors.Error {
+	var cause error
+	for _, desc := range b.causes {
+		if cause == nil {
+			cause = fmt.Errorf("%s", desc)
+			continue
+		}
+		cause = fmt.Errorf("%s: %w", desc, cause)
+	}
+
+	internalMeta := errors.Meta{}
+	for key, value := range b.internalMeta {
+		internalMeta[key] = value
+	}
+	if b.stack {
+		internalMeta[errors.StackKey] = string(debug.Stack())
+	}
+
+	e := errors.NewFromError(b.code, cause, b.msg, errors.SetMeta(b.meta))
+	if len(internalMeta) > 0 {
+		e = e.With(errors.SetInternalMeta(internalMeta))
+	}
+	return e
+}
+
+// Random returns an error of the given code with random but realistic
+// content: meta, cause chain, internal meta and stack.
+func Random(code errors.Code) *errors.Error {
+	return RandomFrom(rand.New(rand.NewSource(rand.Int63())), code)
+}
+
+// RandomFrom is like Random but draws from r, so the fixtures of a seed are
+// reproducible.
+func RandomFrom(r *rand.Rand, code errors.Code) *errors.Error {
+	b := Fixture(code).Meta(errors.RequestIDKey, randomID(r, "req_"))
+
+	for _, i := range r.Perm(len(metaKeys))[:r.Intn(len(metaKeys)+1)] {
+		b.Meta(metaKeys[i], metaValues[i](r))
+	}
+	for i := r.Intn(4); i > 0; i-- {
+		b.Cause(causes[r.Intn(len(causes))])
+	}
+	if r.Intn(2) == 0 {
+		b.InternalMeta("query_ms", r.Intn(5000))
+	}
+	if r.Intn(2) == 0 {
+		b.Stack()
+	}
+	return b.Build()
+}
+
+var (
+	metaKeys   = []string{"account", "bank", "amount", "currency", "transfer_ids"}
+	metaValues = []func(*rand.Rand) interface{}{
+		func(r *rand.Rand) interface{} { return randomID(r, "acc_") },
+		func(r *rand.Rand) interface{} { return banks[r.Intn(len(banks))] },
+		func(r *rand.Rand) interface{} { return r.Intn(1000000) },
+		func(r *rand.Rand) interface{} { return currencies[r.Intn(len(currencies))] },
+		func(r *rand.Rand) interface{} {
+			return []interface{}{randomID(r, "tr_"), randomID(r, "tr_")}
+		},
+	}
+
+	banks      = []string{"banco_estado", "banco_chile", "santander", "bci"}
+	currencies = []string{"CLP", "USD", "EUR"}
+	causes     = []string{
+		"sql: no rows in result set",
+		"dial tcp 10.0.0.12:5432: connect: connection refused",
+		"context deadline exceeded",
+		"get account",
+		"unexpected EOF",
+	}
+)
+
+// randomID returns a random identifier with the given prefix.
+func randomID(r *rand.Rand, prefix string) string {
+	b := make([]byte, 6)
+	r.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
+
+// message returns the registered message of the code.
+func message(code errors.Code) string {
+	for _, info := range errors.Catalog() {
+		if info.Code == code {
+			return info.Message
+		}
+	}
+	return "unexpected error"
+}
@@ -0,0 +1,52 @@
+package errorstest
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/Finciero/errors"
+)
+
+func TestFixture(t *testing.T) {
+	e := Fixture(errors.StatusNotFound).
+		Meta("account", "acc_1").
+		Cause("sql: no rows in result set").
+		Cause("get account").
+		Stack().
+		Build()
+
+	if e.StatusCode != errors.StatusNotFound || e.Message != "not found" {
+		t.Errorf("Build()\n exp: %v\n got: %v\n", errors.NotFound("not found"), e)
+	}
+	if got := e.InternalError.Error(); got != "get account: sql: no rows in result set" {
+		t.Errorf("Build() cause\n exp: %q\n got: %q\n", "get account: sql: no rows in result set", got)
+	}
+	if _, ok := e.InternalMeta[errors.StackKey]; !ok {
+		t.Errorf("Build() stack\n exp: %s set\n got: %v\n", errors.StackKey, e.InternalMeta)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for _, code := range []errors.Code{errors.StatusBadRequest, errors.StatusNotFound, errors.StatusInternalServerError} {
+		first := RandomFrom(rand.New(rand.NewSource(1)), code)
+		second := RandomFrom(rand.New(rand.NewSource(1)), code)
+		if d := errors.Diff(first, second, errors.IgnoreStack()); d != "" {
+			t.Errorf("RandomFrom(%d) with the same seed\n%s", code, d)
+		}
+
+		e := Random(code)
+		if e.StatusCode != code || e.Meta[errors.RequestIDKey] == nil {
+			t.Errorf("Random(%d)\n exp: code %d with %s\n got: %v\n", code, code, errors.RequestIDKey, e)
+		}
+
+		b, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("Marshal(Random(%d)) unexpected error: %v", code, err)
+		}
+		var decoded errors.Error
+		if err := json.Unmarshal(b, &decoded); err != nil || decoded.StatusCode != code {
+			t.Errorf("Unmarshal(Marshal(Random(%d)))\n exp: code %d\n got: %v (%v)\n", code, code, &decoded, err)
+		}
+	}
+}
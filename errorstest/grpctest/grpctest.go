@@ -0,0 +1,132 @@
+// Package grpctest provides an in-process grpc server and client to check
+// that errors survive the trip through the wire, e.g. custom codes and
+// converters.
+package grpctest
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Finciero/errors"
+)
+
+// bufferSize is the size of the in-memory connection buffer.
+const bufferSize = 1 << 20
+
+// Harness is an in-process grpc server, served through
+// errors.UnaryServerInterceptor, and a client connected to it. It must not
+// be used from parallel subtests, as the server shares the errors package
+// configuration, e.g. the mode set by errors.SetMode, with the test.
+type Harness struct {
+	conn   *grpc.ClientConn
+	client healthpb.HealthClient
+	errs   *calls
+}
+
+// New starts a Harness whose server uses an errors.UnaryServerInterceptor
+// with the given options. It is stopped when the test ends.
+func New(t testing.TB, opts ...errors.Option) *Harness {
+	t.Helper()
+
+	lis := bufconn.Listen(bufferSize)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(errors.UnaryServerInterceptor(opts...)))
+
+	h := &Harness{errs: &calls{errs: map[string]error{}}}
+	healthpb.RegisterHealthServer(srv, &server{errs: h.errs})
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		srv.Stop()
+		t.Fatalf("grpctest: dial: %v", err)
+	}
+	h.conn, h.client = conn, healthpb.NewHealthClient(conn)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+	return h
+}
+
+// RoundTrip returns err as received by the client after being returned by a
+// handler of the server.
+func (h *Harness) RoundTrip(ctx context.Context, err error) *errors.Error {
+	id := h.errs.add(err)
+	defer h.errs.take(id)
+
+	_, got := h.client.Check(ctx, &healthpb.HealthCheckRequest{Service: id})
+	return errors.FromGRPC(got)
+}
+
+// AssertRoundTrip fails the test if e is not equal to itself after a
+// RoundTrip, as compared by errors.Diff with the given options. Internal
+// errors and meta only survive it in errors.Debug mode.
+//
+//	h := grpctest.New(t)
+//	grpctest.AssertRoundTrip(t, h, errors.New(StatusInsufficientFunds, "insufficient funds"))
+func AssertRoundTrip(t testing.TB, h *Harness, e *errors.Error, opts ...errors.CompareOption) {
+	t.Helper()
+
+	got := h.RoundTrip(context.Background(), e)
+	if d := errors.Diff(e, got, opts...); d != "" {
+		t.Errorf("grpc round trip of %v (-want +got):\n%s", e, d)
+	}
+}
+
+// calls holds the errors of the pending RoundTrip calls, by call id, so a
+// call failing before reaching the server does not leave its error to the
+// next one.
+type calls struct {
+	sync.Mutex
+	next int
+	errs map[string]error
+}
+
+// add holds err and returns the id of its call.
+func (c *calls) add(err error) string {
+	c.Lock()
+	defer c.Unlock()
+
+	c.next++
+	id := strconv.Itoa(c.next)
+	c.errs[id] = err
+	return id
+}
+
+// take returns the error of the given call, and forgets it.
+func (c *calls) take(id string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	err := c.errs[id]
+	delete(c.errs, id)
+	return err
+}
+
+// server is a health server whose Check returns the error of the call named
+// by the service of the request.
+type server struct {
+	healthpb.UnimplementedHealthServer
+	errs *calls
+}
+
+func (s *server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if err := s.errs.take(req.Service); err != nil {
+		return nil, err
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
@@ -0,0 +1,44 @@
+package grpctest
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/Finciero/errors"
+)
+
+func TestAssertRoundTrip(t *testing.T) {
+	const insufficientFunds errors.Code = 1001
+	if err := errors.RegisterCode(errors.CodeInfo{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: http.StatusPaymentRequired}); err != nil {
+		t.Fatalf("RegisterCode() unexpected error: %v", err)
+	}
+
+	h := New(t)
+
+	tests := []*errors.Error{
+		errors.NotFound("no account", errors.SetMeta(errors.Meta{"account": "a1"})),
+		errors.New(insufficientFunds, "insufficient funds", errors.SetRetryAfter(0)),
+		errors.RateLimit("slow down", errors.AllowFallback()),
+	}
+
+	for _, e := range tests {
+		AssertRoundTrip(t, h, e)
+	}
+
+	if got := h.RoundTrip(context.Background(), stderrors.New("boom")); got.StatusCode != errors.StatusInternalServerError {
+		t.Errorf("RoundTrip(boom)\n exp: %d\n got: %d\n", errors.StatusInternalServerError, got.StatusCode)
+	}
+	if got := h.RoundTrip(context.Background(), nil); got != nil {
+		t.Errorf("RoundTrip(nil)\n exp: <nil>\n got: %v\n", got)
+	}
+
+	// a call failing before reaching the server must not affect the next one
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.RoundTrip(ctx, errors.Forbidden("stale"))
+	if got := h.RoundTrip(context.Background(), errors.NotFound("fresh")); got.Message != "fresh" {
+		t.Errorf("RoundTrip() after a canceled call\n exp: %q\n got: %v\n", "fresh", got)
+	}
+}
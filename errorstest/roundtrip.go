@@ -0,0 +1,151 @@
+package errorstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Finciero/errors"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Encoding is a supported wire encoding of errors.
+type Encoding struct {
+	Name string
+	// RoundTrip encodes the error and decodes it back.
+	RoundTrip func(*errors.Error) (*errors.Error, error)
+	// Expect returns the error RoundTrip is expected to return for the given
+	// one, without the fields the encoding is documented to drop.
+	Expect func(*errors.Error) *errors.Error
+}
+
+// Encodings are the encodings of the errors package checked by
+// AssertRoundTrip.
+var Encodings = []Encoding{
+	{"json", jsonRoundTrip, wireExpect},
+	{"grpc", grpcRoundTrip, wireExpect},
+	{"headers", headersRoundTrip, wireExpect},
+	{"msgpack", msgpackRoundTrip, wireExpect},
+	{"http", httpRoundTrip, wireExpect},
+	{"stream_frame", streamFrameRoundTrip, wireExpect},
+	{"connect", connectRoundTrip, connectExpect},
+}
+
+// AssertRoundTrip fails the test if decoding e encoded with each of the
+// given encodings, all of Encodings if none, does not return e, as compared
+// by errors.Diff with the given options, modulo the fields the encoding is
+// documented to drop. It catches format regressions, e.g. of custom
+// registered codes.
+//
+//	errorstest.AssertRoundTrip(t, errors.New(StatusInsufficientFunds, "insufficient funds"), nil)
+func AssertRoundTrip(t testing.TB, e *errors.Error, encodings []Encoding, opts ...errors.CompareOption) {
+	t.Helper()
+
+	if len(encodings) == 0 {
+		encodings = Encodings
+	}
+	for _, enc := range encodings {
+		got, err := enc.RoundTrip(e)
+		if err != nil {
+			t.Errorf("%s round trip of %v: %v", enc.Name, e, err)
+			continue
+		}
+		if d := errors.Diff(enc.Expect(e), got, opts...); d != "" {
+			t.Errorf("%s round trip of %v (-want +got):\n%s", enc.Name, e, d)
+		}
+	}
+}
+
+func jsonRoundTrip(e *errors.Error) (*errors.Error, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded errors.Error
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+func grpcRoundTrip(e *errors.Error) (*errors.Error, error) {
+	return errors.FromGRPC(e.ToGRPC()), nil
+}
+
+func headersRoundTrip(e *errors.Error) (*errors.Error, error) {
+	h := http.Header{}
+	if err := e.SetHeaders(h); err != nil {
+		return nil, err
+	}
+	return errors.FromHeaders(h), nil
+}
+
+func msgpackRoundTrip(e *errors.Error) (*errors.Error, error) {
+	b, err := msgpack.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded errors.Error
+	if err := msgpack.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+func httpRoundTrip(e *errors.Error) (*errors.Error, error) {
+	rec := httptest.NewRecorder()
+	errors.WriteHTTP(rec, e)
+	return errors.FromHTTPResponse(rec.Result()), nil
+}
+
+func streamFrameRoundTrip(e *errors.Error) (*errors.Error, error) {
+	frame, err := e.StreamFrame()
+	if err != nil {
+		return nil, err
+	}
+	return errors.FromStreamFrame(frame), nil
+}
+
+func connectRoundTrip(e *errors.Error) (*errors.Error, error) {
+	return errors.FromConnect(e.ToConnect()), nil
+}
+
+// wireExpect returns e as decoded from json, grpc, headers or msgpack: Meta
+// values are decoded as json values, e.g. numbers as float64, and the
+// internal error and meta are dropped unless in errors.Debug mode.
+func wireExpect(e *errors.Error) *errors.Error {
+	c := e.Clone()
+	c.Meta = jsonMeta(c.Meta)
+	if errors.CurrentMode() != errors.Debug {
+		c.InternalError, c.InternalMeta = nil, nil
+	} else {
+		c.InternalMeta = jsonMeta(c.InternalMeta)
+	}
+	return c
+}
+
+// connectExpect returns e as decoded from connect, which only carries the
+// code, message and Meta.
+func connectExpect(e *errors.Error) *errors.Error {
+	return errors.New(e.StatusCode, e.Message, errors.SetMeta(jsonMeta(e.Meta)))
+}
+
+// jsonMeta returns m as decoded from json.
+func jsonMeta(m errors.Meta) errors.Meta {
+	if len(m) == 0 {
+		return m
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return m
+	}
+	var decoded errors.Meta
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return m
+	}
+	return decoded
+}
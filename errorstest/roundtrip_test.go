@@ -0,0 +1,25 @@
+package errorstest
+
+import (
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"github.com/Finciero/errors"
+)
+
+func TestAssertRoundTrip(t *testing.T) {
+	const insufficientFunds errors.Code = 1002
+	if err := errors.RegisterCode(errors.CodeInfo{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: http.StatusPaymentRequired}); err != nil {
+		t.Fatalf("RegisterCode() unexpected error: %v", err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, code := range []errors.Code{errors.StatusBadRequest, errors.StatusNotFound, insufficientFunds} {
+		AssertRoundTrip(t, RandomFrom(r, code), nil)
+	}
+
+	errors.SetMode(errors.Debug)
+	defer errors.SetMode(errors.Production)
+	AssertRoundTrip(t, RandomFrom(r, errors.StatusInternalServerError), Encodings[:4])
+}
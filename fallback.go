@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/status"
+)
+
+// AllowFallback sets FallbackAllowed, telling callers they may serve stale
+// or deferred data instead of failing.
+//
+//	errors.InternalServerFromError(err, "bank unavailable", errors.AllowFallback())
+func AllowFallback() errorParamsSetter {
+	return func(e *Error) {
+		e.FallbackAllowed = true
+	}
+}
+
+// IsFallbackAllowed reports whether err, or an *Error or grpc error in its
+// chain, allows callers to fall back to stale or deferred data. Unlike
+// FromGRPC, it only reads the grpc status, without converters, stacks or
+// decode failure hooks.
+func IsFallbackAllowed(err error) bool {
+	for cur, i := err, 0; cur != nil && i < maxChainDepth; cur, i = unwrap(cur), i+1 {
+		if e, ok := cur.(*Error); ok {
+			return e.FallbackAllowed
+		}
+	}
+
+	s, ok := status.FromError(err)
+	if !ok || s == nil {
+		return false
+	}
+	desc := s.Message()
+	if payload, ok := v2Payload(s); ok {
+		desc = payload
+	}
+
+	var raw struct {
+		Fallback bool `json:"fallback_allowed"`
+	}
+	return json.Unmarshal([]byte(unquote(desc)), &raw) == nil && raw.Fallback
+}
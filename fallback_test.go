@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFallbackAllowed(t *testing.T) {
+	e := InternalServer("bank unavailable", AllowFallback())
+
+	var decoded Error
+	b, _ := json.Marshal(e)
+	json.Unmarshal(b, &decoded)
+
+	tests := []struct {
+		err error
+		exp bool
+	}{
+		{nil, false},
+		{e, true},
+		{fmt.Errorf("balance: %w", e), true},
+		{e.ToGRPC(), true},
+		{&decoded, true},
+		{InternalServer("bank down"), false},
+		{InternalServer("bank down").ToGRPC(), false},
+		{errors.New("boom"), false},
+		{status.Error(codes.Internal, `{"msg":"truncated`), false},
+	}
+
+	failures := DecodeFailures()
+	for _, tt := range tests {
+		if got := IsFallbackAllowed(tt.err); got != tt.exp {
+			t.Errorf("IsFallbackAllowed(%v)\n exp: %v\n got: %v\n", tt.err, tt.exp, got)
+		}
+	}
+	if got := DecodeFailures(); got != failures {
+		t.Errorf("IsFallbackAllowed() decode failures\n exp: %d\n got: %d\n", failures, got)
+	}
+
+	defer SetConfig(CurrentConfig())
+	SetWireFormat(WireV2)
+	if !IsFallbackAllowed(e.ToGRPC()) {
+		t.Errorf("IsFallbackAllowed(%v) with WireV2\n exp: true\n got: false\n", e)
+	}
+}
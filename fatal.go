@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes returned by ExitCode, following sysexits(3).
+const (
+	ExitUsage       = 64
+	ExitNoInput     = 66
+	ExitUnavailable = 69
+	ExitSoftware    = 70
+	ExitTempFail    = 75
+	ExitNoPerm      = 77
+)
+
+var (
+	stderr io.Writer = os.Stderr
+	exit             = os.Exit
+)
+
+// ExitCode returns the process exit code mapped from the error StatusCode.
+func (e *Error) ExitCode() int {
+	switch e.StatusCode {
+	case StatusBadRequest, StatusUnprocessableEntity, StatusNotAcceptable:
+		return ExitUsage
+	case StatusUnauthorized, StatusForbidden, StatusPaymentRequired:
+		return ExitNoPerm
+	case StatusNotFound:
+		return ExitNoInput
+	case StatusTooManyRequests:
+		return ExitTempFail
+	default:
+		return ExitSoftware
+	}
+}
+
+// Detail returns a human friendly, multi line representation of the error,
+// with the Meta in the order of MetaKeys.
+func (e *Error) Detail() string {
+	str := fmt.Sprintf("%s (%d)", e.ErrorID(), e.StatusCode)
+
+	if len(e.Message) > 0 {
+		str += ": " + e.Message
+	}
+
+	if e.InternalError != nil {
+		str += "\n  cause: " + e.desc()
+	}
+
+	for _, key := range e.MetaKeys() {
+		str += fmt.Sprintf("\n  %s: %v", key, e.Meta[key])
+	}
+
+	return str
+}
+
+// Fatal prints the error detail to stderr, reports it to the registered
+// sinks and exits with the mapped exit code. It does nothing if err is nil.
+//
+//	func main() {
+//		errors.Fatal(run())
+//	}
+func Fatal(err error) {
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+
+	fmt.Fprintln(stderr, e.Detail())
+	Report(e)
+	Flush()
+	exit(e.ExitCode())
+}
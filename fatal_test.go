@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+type testSink struct {
+	reported []*Error
+	flushed  int
+}
+
+func (s *testSink) Report(e *Error) { s.reported = append(s.reported, e) }
+func (s *testSink) Flush()          { s.flushed++ }
+
+func TestFatal(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		code = -1
+		sink = &testSink{}
+	)
+
+	defer func(w io.Writer, fn func(int)) { stderr, exit = w, fn }(stderr, exit)
+	stderr = &buf
+	exit = func(c int) { code = c }
+	AddSink(sink)
+	defer UpdateConfig(func(c *Config) { c.Sinks = nil })
+
+	tests := []struct {
+		err  error
+		code int
+		out  string
+	}{
+		{nil, -1, ""},
+		{NotFound("no account"), ExitNoInput, "not_found (404): no account\n"},
+		{NotFound("no account", SetMeta(Meta{"user": "u1", "account": "a1", "bank": "b1"})), ExitNoInput,
+			"not_found (404): no account\n  account: a1\n  bank: b1\n  user: u1\n"},
+		{errors.New("boom"), ExitSoftware, "internal_server (500): unexpected error\n  cause: boom\n"},
+	}
+
+	for _, tt := range tests {
+		buf.Reset()
+		code = -1
+		Fatal(tt.err)
+
+		if code != tt.code {
+			t.Errorf("Fatal(%v) exit code\n exp: %d\n got: %d\n", tt.err, tt.code, code)
+		}
+		if buf.String() != tt.out {
+			t.Errorf("Fatal(%v) output\n exp: %q\n got: %q\n", tt.err, tt.out, buf.String())
+		}
+	}
+
+	if len(sink.reported) != 3 || sink.flushed != 3 {
+		t.Errorf("Fatal sinks\n exp: 3 reported, 3 flushed\n got: %d reported, %d flushed\n", len(sink.reported), sink.flushed)
+	}
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// FieldKey is the Meta key holding the field of a field error.
+const FieldKey = "field"
+
+// FieldError returns a bad_request Error for an invalid field, to be joined
+// into the InternalError of a composite error.
+//
+//	errors.InvalidParamsFromError(stderrors.Join(
+//		errors.FieldError("name", "required"),
+//		errors.FieldError("email", "invalid format"),
+//	), "invalid params")
+func FieldError(field, msg string, setters ...errorParamsSetter) *Error {
+	setters = append([]errorParamsSetter{SetMeta(Meta{FieldKey: field})}, setters...)
+	return newError(StatusBadRequest, nil, msg, setters)
+}
+
+// fieldViolations returns the errdetails.BadRequest of the field errors of
+// a composite error, nil if there are none.
+func (e *Error) fieldViolations() *errdetails.BadRequest {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, sub := range e.Errors() {
+		field, ok := sub.Meta[FieldKey]
+		if !ok {
+			continue
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fmt.Sprint(field),
+			Description: sub.Message,
+		})
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &errdetails.BadRequest{FieldViolations: violations}
+}
+
+// setFieldErrors sets the field errors carried in the errdetails.BadRequest
+// of s as the InternalError of e, unless it already has one.
+func (e *Error) setFieldErrors(s *status.Status) {
+	if e.InternalError != nil {
+		return
+	}
+
+	for _, detail := range s.Details() {
+		d, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		subs := make([]*Error, len(d.FieldViolations))
+		for i, v := range d.FieldViolations {
+			subs[i] = &Error{StatusCode: StatusBadRequest, Message: v.Description, Meta: Meta{FieldKey: v.Field}}
+		}
+		if len(subs) > 0 {
+			e.InternalError = joinErrors(subs)
+		}
+		return
+	}
+}
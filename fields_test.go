@@ -0,0 +1,54 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFieldViolations(t *testing.T) {
+	e := InvalidParamsFromError(stderrors.Join(
+		FieldError("name", "required"),
+		FieldError("email", "invalid format"),
+		stderrors.New("not a field error"),
+	), "invalid params")
+
+	var violations *errdetails.BadRequest
+	for _, detail := range e.GRPCStatus().Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			violations = d
+		}
+	}
+	if violations == nil || len(violations.FieldViolations) != 2 || violations.FieldViolations[1].Field != "email" {
+		t.Fatalf("GRPCStatus() BadRequest\n exp: name and email violations\n got: %v\n", violations)
+	}
+
+	foreign, _ := status.New(codes.InvalidArgument, "invalid").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "amount", Description: "must be positive"}},
+	})
+
+	tests := []struct {
+		err    error
+		fields []string
+	}{
+		{e.ToGRPC(), []string{"name", "email"}},
+		{foreign.Err(), []string{"amount"}},
+		{BadRequest("plain").ToGRPC(), nil},
+	}
+
+	for _, tt := range tests {
+		got := FromGRPC(tt.err).Errors()
+		if len(got) != len(tt.fields) {
+			t.Errorf("FromGRPC(%v).Errors()\n exp: %v\n got: %v\n", tt.err, tt.fields, got)
+			continue
+		}
+		for i, field := range tt.fields {
+			if got[i].Meta[FieldKey] != field {
+				t.Errorf("FromGRPC(%v).Errors()[%d]\n exp: %s\n got: %v\n", tt.err, i, field, got[i])
+			}
+		}
+	}
+}
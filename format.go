@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// Format implements fmt.Formatter. %v prints Error(), %+v prints the full
+// cause chain with meta and stack, one error per block, and %#v the default
+// Go syntax representation.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		formatChain(s, e)
+	case verb == 'v' && s.Flag('#'):
+		formatGoSyntax(s, e)
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// formatGoSyntax prints the exported fields of e in Go syntax, the
+// unexported ones are left out.
+func formatGoSyntax(w io.Writer, e *Error) {
+	if e == nil {
+		io.WriteString(w, "(*errors.Error)(nil)")
+		return
+	}
+
+	internal := "error(nil)"
+	if e.InternalError != nil {
+		internal = fmt.Sprintf("%#v", e.InternalError)
+	}
+
+	fmt.Fprintf(w, "&errors.Error{StatusCode:%d, Meta:%#v, Message:%q, UserMessage:%q, FallbackAllowed:%t, Priority:%q, InternalError:%s, InternalMeta:%#v}",
+		e.StatusCode, e.Meta, e.Message, e.UserMessage, e.FallbackAllowed, e.Priority, internal, e.InternalMeta)
+}
+
+// FormatError implements xerrors.Formatter.
+func (e *Error) FormatError(p xerrors.Printer) error {
+	p.Printf("status_code=%d error_id=%q", e.StatusCode, e.ErrorID())
+	if len(e.Message) > 0 {
+		p.Printf(" msg=%q", e.Message)
+	}
+
+	if p.Detail() {
+		keys := make([]string, 0, len(e.Meta))
+		for key := range e.Meta {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			p.Printf("%s=%v\n", key, e.Meta[key])
+		}
+
+		for _, frame := range e.StackTrace() {
+			p.Printf("%+s:%d\n", frame, frame)
+		}
+	}
+
+	return e.Unwrap()
+}
+
+// formatChain writes every error of the cause chain of err, outermost first.
+// The description of errors wrapped with fmt.Errorf is trimmed to their own
+// annotation.
+func formatChain(w io.Writer, err error) {
+	loops := cyclic(err)
+	for i := 0; err != nil && i < maxChainDepth; i++ {
+		if i > 0 {
+			io.WriteString(w, "\n  - ")
+		}
+
+		next := unwrap(err)
+		e, ok := err.(*Error)
+		if !ok {
+			if loops {
+				io.WriteString(w, cycleDesc)
+				return
+			}
+			io.WriteString(w, annotation(err))
+			err = next
+			continue
+		}
+
+		fmt.Fprintf(w, "status_code=%d error_id=%q", e.StatusCode, e.ErrorID())
+		if len(e.Message) > 0 {
+			fmt.Fprintf(w, " msg=%q", e.Message)
+		}
+
+		keys := make([]string, 0, len(e.Meta))
+		for key := range e.Meta {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(w, "\n    %s=%v", key, e.Meta[key])
+		}
+
+		for _, frame := range e.StackTrace() {
+			fmt.Fprintf(w, "\n    %+s:%d", frame, frame)
+		}
+
+		err = next
+	}
+}
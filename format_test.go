@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	err := InternalServerFromError(
+		fmt.Errorf("get balance: %w", NotFoundFromError(io.EOF, "no account", SetMeta(Meta{"account": "a1"}))),
+		"",
+	)
+
+	tests := []struct {
+		format string
+		exp    string
+	}{
+		{"%v", err.Error()},
+		{"%s", err.Error()},
+		{"%q", fmt.Sprintf("%q", err.Error())},
+		{"%+v", `status_code=500 error_id="internal_server"
+  - get balance
+  - status_code=404 error_id="not_found" msg="no account"
+    account=a1
+  - EOF`},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.exp {
+			t.Errorf("Sprintf(%q)\n exp: %s\n got: %s\n", tt.format, tt.exp, got)
+		}
+	}
+
+	goSyntax := []struct {
+		err *Error
+		exp string
+	}{
+		{
+			err: &Error{StatusCode: StatusNotFound, Meta: Meta{"account": "a1"}, Message: "no account"},
+			exp: `&errors.Error{StatusCode:404, Meta:errors.Meta{"account":"a1"}, Message:"no account", UserMessage:"", FallbackAllowed:false, Priority:"", InternalError:error(nil), InternalMeta:errors.Meta(nil)}`,
+		},
+		{
+			err: nil,
+			exp: `(*errors.Error)(nil)`,
+		},
+	}
+
+	for _, tt := range goSyntax {
+		if got := fmt.Sprintf("%#v", tt.err); got != tt.exp {
+			t.Errorf("Sprintf(%q)\n exp: %s\n got: %s\n", "%#v", tt.exp, got)
+		}
+	}
+}
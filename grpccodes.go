@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// UseCanonicalCodes makes ToGRPC encode errors with the canonical gRPC code
+// closest to their status code, e.g. codes.NotFound, carrying the status code
+// in the status details, so gRPC tooling that only understands canonical
+// codes, like retry policies, behaves correctly. Disabled by default, in
+// which case the status code itself is used as the gRPC code. FromGRPC
+// decodes both.
+func UseCanonicalCodes(enabled bool) {
+	UpdateConfig(func(c *Config) { c.CanonicalCodes = enabled })
+}
+
+// grpcCode returns the gRPC code the status code is encoded with in the
+// given configuration.
+func (c Code) grpcCode(cfg *Config) codes.Code {
+	if cfg.CanonicalCodes {
+		return c.Canonical()
+	}
+	return codes.Code(c)
+}
+
+// withCodeDetail returns s with the status code in its details when
+// canonical codes are used.
+func withCodeDetail(s *status.Status, c Code, cfg *Config) *status.Status {
+	if !cfg.CanonicalCodes {
+		return s
+	}
+
+	detail, err := structpb.NewStruct(map[string]interface{}{"status_code": float64(c)})
+	if err != nil {
+		return s
+	}
+	return withDetails(s, detail)
+}
+
+// detailCode returns the status code carried in the details of s.
+func detailCode(s *status.Status) (Code, bool) {
+	for _, detail := range s.Details() {
+		st, ok := detail.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		if v, ok := st.Fields["status_code"]; ok && v.GetNumberValue() != 0 {
+			return Code(v.GetNumberValue()), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUseCanonicalCodes(t *testing.T) {
+	UseCanonicalCodes(true)
+	defer UseCanonicalCodes(false)
+
+	tests := []struct {
+		err  *Error
+		code codes.Code
+	}{
+		{NotFound("no account", SetMeta(Meta{"account": "a1"})), codes.NotFound},
+		{Forbidden("no access"), codes.PermissionDenied},
+		{RateLimit("slow down"), codes.ResourceExhausted},
+		{New(StatusNotAcceptable, "not acceptable"), codes.InvalidArgument},
+		{MethodNotAllowed("use POST"), codes.Unimplemented},
+	}
+
+	for _, tt := range tests {
+		err := tt.err.ToGRPC()
+		if got := status.Code(err); got != tt.code {
+			t.Errorf("status.Code(%v.ToGRPC())\n exp: %v\n got: %v\n", tt.err, tt.code, got)
+		}
+		if got := GRPCCodeOf(tt.err); got != tt.code {
+			t.Errorf("GRPCCodeOf(%v)\n exp: %v\n got: %v\n", tt.err, tt.code, got)
+		}
+		if got := FromGRPC(err); !got.Equal(tt.err) {
+			t.Errorf("FromGRPC(%v.ToGRPC())\n%s", tt.err, Diff(tt.err, got))
+		}
+		if got := CodeOf(err); got != tt.err.StatusCode {
+			t.Errorf("CodeOf(%v.ToGRPC())\n exp: %d\n got: %d\n", tt.err, tt.err.StatusCode, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Header keys of the errors set by SetHeaders.
+const (
+	// ErrorHeader holds the json object of the error, base64url encoded.
+	ErrorHeader = "X-Error"
+	// ErrorCodeHeader holds the status code of the error, for proxies and
+	// access logs.
+	ErrorCodeHeader = "X-Error-Code"
+	// ErrorIDHeader holds the error id of the error, for proxies and access
+	// logs.
+	ErrorIDHeader = "X-Error-Id"
+)
+
+// SetHeaders sets the error into h, for responses whose body can not carry
+// it, e.g. the response of a HEAD request or the trailers of a streamed
+// response failing midway. The error is encoded as MarshalJSON does, so keep
+// its Meta small: proxies usually limit headers to a few kilobytes.
+func (e *Error) SetHeaders(h http.Header) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	h.Set(ErrorHeader, base64.RawURLEncoding.EncodeToString(b))
+	h.Set(ErrorCodeHeader, strconv.Itoa(int(e.StatusCode)))
+	h.Set(ErrorIDHeader, e.ErrorID())
+	return nil
+}
+
+// FromHeaders returns the error set by SetHeaders into h, nil if there is
+// none.
+func FromHeaders(h http.Header) *Error {
+	value := h.Get(ErrorHeader)
+	if len(value) == 0 {
+		return nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return InternalServerFromError(err, UnexpectedMsg)
+	}
+
+	var e Error
+	if err := json.Unmarshal(b, &e); err != nil {
+		return InternalServerFromError(err, UnexpectedMsg)
+	}
+	return &e
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSetHeadersFromHeaders(t *testing.T) {
+	tests := []struct {
+		err *Error
+	}{
+		{BadRequest("let's go\nnow", SetMeta(Meta{"hi": "ho"}))},
+		{NotFound("no señal", func(e *Error) { e.UserMessage = "Cuenta no encontrada" })},
+		{New(418, "teapot")},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		if err := tt.err.SetHeaders(h); err != nil {
+			t.Fatalf("SetHeaders(%v) unexpected error: %v", tt.err, err)
+		}
+		if got := FromHeaders(h); !reflect.DeepEqual(got, tt.err) {
+			t.Errorf("FromHeaders(%v)\n exp: %v\n got: %v\n", h, tt.err, got)
+		}
+	}
+
+	h := http.Header{}
+	NotFound("no account").SetHeaders(h)
+	if h.Get(ErrorCodeHeader) != "404" || h.Get(ErrorIDHeader) != "not_found" {
+		t.Errorf("SetHeaders()\n exp: 404 not_found\n got: %s %s\n", h.Get(ErrorCodeHeader), h.Get(ErrorIDHeader))
+	}
+
+	if got := FromHeaders(http.Header{}); got != nil {
+		t.Errorf("FromHeaders() without error\n exp: nil\n got: %v\n", got)
+	}
+	if got := FromHeaders(http.Header{ErrorHeader: {"%%%"}}); got.StatusCode != StatusInternalServerError {
+		t.Errorf("FromHeaders() of an invalid header\n exp: %d\n got: %d\n", StatusInternalServerError, got.StatusCode)
+	}
+}
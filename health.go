@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthThreshold is the number of errors with a code, within the window of
+// an Aggregator, from which a service is considered not serving.
+type HealthThreshold struct {
+	Code  Code
+	Count int
+}
+
+// Health maps the errors grouped by an Aggregator into a health status, so
+// degraded dependencies flip readiness automatically.
+//
+//	agg := errors.NewAggregator(5 * time.Minute)
+//	errors.AddSink(agg)
+//
+//	h := errors.NewHealth(agg, errors.HealthThreshold{Code: errors.StatusInternalServerError, Count: 50})
+//	mux.Handle("/healthz", h)
+//	go h.Update(ctx, healthServer, "", 10*time.Second)
+type Health struct {
+	aggregator *Aggregator
+	thresholds []HealthThreshold
+}
+
+// NewHealth returns a Health reporting not serving once any of the given
+// thresholds is reached.
+func NewHealth(a *Aggregator, thresholds ...HealthThreshold) *Health {
+	return &Health{aggregator: a, thresholds: thresholds}
+}
+
+// Status returns the health status matching the errors within the window of
+// the Aggregator.
+func (h *Health) Status() healthpb.HealthCheckResponse_ServingStatus {
+	counts := map[Code]int{}
+	for _, g := range h.aggregator.Groups() {
+		counts[g.StatusCode] += g.Count
+	}
+
+	for _, t := range h.thresholds {
+		if t.Count > 0 && counts[t.Code] >= t.Count {
+			return healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// ServeHTTP writes the Status, with http.StatusServiceUnavailable if it is
+// not serving.
+func (h *Health) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := h.Status()
+
+	w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	if status != healthpb.HealthCheckResponse_SERVING {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write([]byte(status.String()))
+}
+
+// Update sets the Status of the given service into a grpc health server
+// every interval, until ctx is done.
+func (h *Health) Update(ctx context.Context, server *health.Server, service string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		server.SetServingStatus(service, h.Status())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
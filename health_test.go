@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealth(t *testing.T) {
+	agg := NewAggregator(time.Minute)
+	h := NewHealth(agg, HealthThreshold{Code: StatusInternalServerError, Count: 3})
+
+	tests := []struct {
+		report *Error
+		exp    healthpb.HealthCheckResponse_ServingStatus
+		code   int
+	}{
+		{NotFound("no account"), healthpb.HealthCheckResponse_SERVING, http.StatusOK},
+		{InternalServer("db down"), healthpb.HealthCheckResponse_SERVING, http.StatusOK},
+		{InternalServer("timeout"), healthpb.HealthCheckResponse_SERVING, http.StatusOK},
+		{InternalServer("db down"), healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		agg.Report(tt.report)
+
+		if got := h.Status(); got != tt.exp {
+			t.Errorf("Status() after %v\n exp: %v\n got: %v\n", tt.report, tt.exp, got)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if rec.Code != tt.code || rec.Body.String() != tt.exp.String() {
+			t.Errorf("ServeHTTP() after %v\n exp: %d %v\n got: %d %s\n", tt.report, tt.code, tt.exp, rec.Code, rec.Body)
+		}
+	}
+
+	server := health.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.Update(ctx, server, "accounts", time.Second)
+
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "accounts"})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Update()\n exp: %v\n got: %v (%v)\n", healthpb.HealthCheckResponse_NOT_SERVING, resp, err)
+	}
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// maxDecodeFailureData limits the raw data passed to the decode failure
+// hook.
+const maxDecodeFailureData = 1 << 10
+
+var (
+	encodeFailures uint64
+	decodeFailures uint64
+
+	hooksMu           sync.RWMutex
+	encodeFailureHook func(e *Error, err error)
+	decodeFailureHook func(raw []byte, err error)
+)
+
+// OnEncodeFailure sets a function called every time an error can not be
+// fully encoded by ToGRPC, e.g. to log it or increment a metric.
+func OnEncodeFailure(fn func(e *Error, err error)) {
+	hooksMu.Lock()
+	encodeFailureHook = fn
+	hooksMu.Unlock()
+}
+
+// EncodeFailures returns the number of errors that could not be fully
+// encoded by ToGRPC.
+func EncodeFailures() uint64 {
+	return atomic.LoadUint64(&encodeFailures)
+}
+
+// OnDecodeFailure sets a function called every time FromGRPC or
+// FromHTTPResponse can not parse an error payload, e.g. to increment a metric
+// when a service starts emitting an incompatible format. raw holds the
+// offending payload, truncated to 1KB. grpc descriptions that are not a json
+// object, e.g. the ones of transport errors, are not reported.
+func OnDecodeFailure(fn func(raw []byte, err error)) {
+	hooksMu.Lock()
+	decodeFailureHook = fn
+	hooksMu.Unlock()
+}
+
+// DecodeFailures returns the number of error payloads that could not be
+// parsed by FromGRPC or FromHTTPResponse.
+func DecodeFailures() uint64 {
+	return atomic.LoadUint64(&decodeFailures)
+}
+
+// encodeFailed records the failure to encode e.
+func encodeFailed(e *Error, err error) {
+	atomic.AddUint64(&encodeFailures, 1)
+
+	hooksMu.RLock()
+	hook := encodeFailureHook
+	hooksMu.RUnlock()
+
+	if hook != nil {
+		hook(e, err)
+	}
+}
+
+// decodeFailed records the failure to parse the raw error payload.
+func decodeFailed(raw []byte, err error) {
+	atomic.AddUint64(&decodeFailures, 1)
+
+	hooksMu.RLock()
+	hook := decodeFailureHook
+	hooksMu.RUnlock()
+
+	if hook != nil {
+		if len(raw) > maxDecodeFailureData {
+			raw = raw[:maxDecodeFailureData]
+		}
+		hook(raw, err)
+	}
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// ErrorPage is the data of the html error pages, see WriteHTMLRequest.
+type ErrorPage struct {
+	Status      int    // http status, e.g. 404
+	StatusText  string // e.g. "Not Found"
+	Code        Code   // status code of the error
+	ErrorID     string // e.g. "not_found"
+	UserMessage string // user message, or message, of the error
+	Fingerprint string // fingerprint of the error, see Error.Fingerprint
+	ReferenceID string // request id, or fingerprint, to quote to support
+	HelpURL     string // Meta value of HelpURLKey, if any
+}
+
+var errorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.StatusText}}</title></head>
+<body>
+<h1>{{.Status}} {{.StatusText}}</h1>
+{{with .UserMessage}}<p>{{.}}</p>
+{{end}}{{with .ReferenceID}}<p><small>Reference: {{.}}</small></p>
+{{end}}</body>
+</html>
+`))
+
+// SetErrorPage sets the html/template of the error pages written by
+// WriteHTMLRequest, executed with an ErrorPage, nil to use the default
+// template.
+//
+//	errors.SetErrorPage(template.Must(template.ParseFiles("error.html")))
+func SetErrorPage(t *template.Template) {
+	UpdateConfig(func(c *Config) { c.ErrorPage = t })
+}
+
+// WriteHTMLRequest is like WriteHTTPRequest but writes a minimal html error
+// page when the request Accept header includes text/html, for browser facing
+// endpoints, e.g. redirects.
+func WriteHTMLRequest(w http.ResponseWriter, r *http.Request, err error) {
+	if !strings.Contains(r.Header.Get("Accept"), "text/html") {
+		WriteHTTPRequest(w, r, err)
+		return
+	}
+
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+
+	ctx := r.Context()
+	e = e.withContextMeta(ctx).withDeadline(ctx).withConfig(ctx).Localize(languageTags(r)...)
+	logError(e)
+	e = e.sanitized()
+
+	page := errorPage
+	if t := e.cfg().ErrorPage; t != nil {
+		page = t
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	if allow := e.allow(); len(allow) > 0 {
+		w.Header().Set("Allow", allow)
+	}
+	w.WriteHeader(e.StatusCode.httpStatus())
+	page.Execute(w, e.page()) // there is no much more to do in case of failure
+}
+
+// page returns the html error page data of the error.
+func (e *Error) page() ErrorPage {
+	status := e.StatusCode.httpStatus()
+	p := ErrorPage{
+		Status:      status,
+		StatusText:  http.StatusText(status),
+		Code:        e.StatusCode,
+		ErrorID:     e.ErrorID(),
+		UserMessage: e.UserMessage,
+		Fingerprint: e.Fingerprint(),
+	}
+	if len(p.UserMessage) == 0 {
+		p.UserMessage = e.Message
+	}
+	if fingerprint, ok := e.Meta[FingerprintKey].(string); ok {
+		p.Fingerprint = fingerprint
+	}
+	if helpURL, ok := e.Meta[HelpURLKey].(string); ok {
+		p.HelpURL = helpURL
+	}
+	p.ReferenceID = p.Fingerprint
+	if id, ok := e.Meta[RequestIDKey].(string); ok && len(id) > 0 {
+		p.ReferenceID = id
+	}
+	return p
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTMLRequest(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	SetMode(Sanitized)
+
+	tests := []struct {
+		accept      string
+		err         error
+		contentType string
+		allow       string
+		exp         []string
+	}{
+		{
+			accept:      "text/html,application/xhtml+xml",
+			err:         NotFound("no <account>", SetMeta(Meta{RequestIDKey: "r1"})),
+			contentType: "text/html; charset=UTF-8",
+			exp:         []string{"<h1>404 Not Found</h1>", "<p>no &lt;account&gt;</p>", "Reference: r1"},
+		},
+		{
+			accept:      "text/html",
+			err:         InternalServer("db down"),
+			contentType: "text/html; charset=UTF-8",
+			exp:         []string{"<h1>500 Internal Server Error</h1>", "<p>" + UnexpectedMsg + "</p>", "Reference: " + InternalServer("db down").Fingerprint()},
+		},
+		{
+			accept:      "text/html",
+			err:         MethodNotAllowed("use POST", SetMeta(Meta{AllowKey: []string{"POST", "PUT"}})),
+			contentType: "text/html; charset=UTF-8",
+			allow:       "POST, PUT",
+			exp:         []string{"<h1>405 Method Not Allowed</h1>"},
+		},
+		{
+			accept:      "application/json",
+			err:         NotFound("no account"),
+			contentType: "application/json; charset=UTF-8",
+			exp:         []string{`"msg":"no account"`},
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/callback", nil)
+		r.Header.Set("Accept", tt.accept)
+		rec := httptest.NewRecorder()
+		WriteHTMLRequest(rec, r, tt.err)
+
+		if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("WriteHTMLRequest(%q) content type\n exp: %q\n got: %q\n", tt.accept, tt.contentType, got)
+		}
+		if got := rec.Header().Get("Allow"); got != tt.allow {
+			t.Errorf("WriteHTMLRequest(%q) allow\n exp: %q\n got: %q\n", tt.accept, tt.allow, got)
+		}
+		for _, exp := range tt.exp {
+			if got := rec.Body.String(); !strings.Contains(got, exp) {
+				t.Errorf("WriteHTMLRequest(%q) body\n exp: %s\n got: %s\n", tt.accept, exp, got)
+			}
+		}
+	}
+}
+
+func TestSetErrorPage(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	SetErrorPage(template.Must(template.New("branded").Parse(`{{.Code}} {{.ErrorID}} {{.UserMessage}} {{.Fingerprint}} {{.HelpURL}}`)))
+
+	err := NotFound("no account", SetMeta(Meta{HelpURLKey: "https://help.finciero.com/404"}))
+	r := httptest.NewRequest(http.MethodGet, "/callback", nil)
+	r.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	WriteHTMLRequest(rec, r, err)
+
+	exp := "not_found not_found no account " + err.Fingerprint() + " https://help.finciero.com/404"
+	if got := rec.Body.String(); got != exp {
+		t.Errorf("WriteHTMLRequest() with SetErrorPage\n exp: %q\n got: %q\n", exp, got)
+	}
+}
@@ -0,0 +1,219 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// maxBodySize limits how much of a response body is read when decoding an
+// error from it.
+const maxBodySize = 1 << 20
+
+// UpstreamResponseKey is the InternalMeta key holding a snapshot of the
+// failed upstream response an error was decoded from: its status, selected
+// headers and the first bytes of its body.
+const UpstreamResponseKey = "upstream_response"
+
+// maxEvidenceBody limits the body kept in the upstream response snapshot.
+const maxEvidenceBody = 1 << 10
+
+// evidenceHeaders are the headers kept in the upstream response snapshot.
+var evidenceHeaders = []string{"Content-Type", "Date", "Retry-After", "Server", "X-Request-Id"}
+
+// FromHTTPResponse returns a new Error from a failed http response. If the
+// body was encoded with MarshalJSON then the full Error is returned,
+// otherwise a snapshot of the response is kept in the InternalMeta under
+// UpstreamResponseKey. The body is consumed and closed.
+func FromHTTPResponse(resp *http.Response) *Error {
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return InternalServerFromError(err, UnexpectedMsg, SetInternalMeta(Meta{UpstreamResponseKey: evidence(resp, nil)}))
+	}
+
+	var e Error
+	if err := json.Unmarshal(body, &e); err != nil || e.StatusCode == 0 {
+		if len(body) > 0 {
+			if err == nil {
+				err = fmt.Errorf("errors: missing status_code")
+			}
+			decodeFailed(body, err)
+		}
+		return New(Code(resp.StatusCode), http.StatusText(resp.StatusCode), SetInternalMeta(Meta{UpstreamResponseKey: evidence(resp, body)}))
+	}
+
+	return &e
+}
+
+// evidence returns the snapshot of the upstream response with the given
+// body.
+func evidence(resp *http.Response, body []byte) Meta {
+	headers := map[string]string{}
+	for _, key := range evidenceHeaders {
+		if value := resp.Header.Get(key); len(value) > 0 {
+			headers[key] = value
+		}
+	}
+
+	if len(body) > maxEvidenceBody {
+		body = body[:maxEvidenceBody]
+	}
+	return Meta{
+		"status":  resp.StatusCode,
+		"headers": headers,
+		"body":    string(body),
+	}
+}
+
+// DecodeResponse decodes a successful response body into the value pointed
+// by into, or a failed one into the returned Error. The body is consumed and
+// closed.
+func DecodeResponse(resp *http.Response, into interface{}) *Error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return FromHTTPResponse(resp)
+	}
+	defer resp.Body.Close()
+
+	if into == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil && err != io.EOF {
+		return InternalServerFromError(err, UnexpectedMsg)
+	}
+
+	return nil
+}
+
+// WriteHTTP writes err as a json response with the matching status code,
+// logging it with the Logger. Errors that are not an *Error are written as
+// internal_server errors.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+	logError(e)
+	e = e.sanitized()
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if allow := e.allow(); len(allow) > 0 {
+		w.Header().Set("Allow", allow)
+	}
+	w.WriteHeader(e.StatusCode.httpStatus())
+	json.NewEncoder(w).Encode(e) // there is no much more to do in case of failure
+}
+
+// WriteHTTPRequest is like WriteHTTP but sets the UserMessage translated to
+// the language that best matches the request Accept-Language header, adds
+// the Meta derived from the request context and the deadline of
+// request_timeout errors, see SetDeadline, and uses the configuration of the
+// request context, see WithConfig.
+func WriteHTTPRequest(w http.ResponseWriter, r *http.Request, err error) {
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+
+	ctx := r.Context()
+	WriteHTTP(w, e.withContextMeta(ctx).withDeadline(ctx).withConfig(ctx).Localize(languageTags(r)...))
+}
+
+// sanitized returns the error sanitized, reporting the original, if the mode
+// of its configuration is Sanitized.
+func (e *Error) sanitized() *Error {
+	if e.cfg().Mode != Sanitized {
+		return e
+	}
+	sanitized := e.Sanitize()
+	if sanitized != e {
+		Report(e)
+		sanitized.config = e.config
+	}
+	return sanitized
+}
+
+// languageTags returns the languages of the request Accept-Language header.
+func languageTags(r *http.Request) []language.Tag {
+	tags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	return tags
+}
+
+// AllowKey is the Meta key holding the methods allowed by the resource of a
+// method_not_allowed error, written by the http writers as the Allow header.
+const AllowKey = "allow"
+
+// SetAllow sets the methods allowed by the resource into the Meta of the
+// error.
+//
+//	errors.MethodNotAllowed("method not allowed", errors.SetAllow(http.MethodGet, http.MethodHead))
+func SetAllow(methods ...string) errorParamsSetter {
+	return SetMeta(Meta{AllowKey: methods})
+}
+
+// allow returns the Allow header of a method_not_allowed error.
+func (e *Error) allow() string {
+	if e.StatusCode.httpStatus() != http.StatusMethodNotAllowed {
+		return ""
+	}
+
+	switch methods := e.Meta[AllowKey].(type) {
+	case string:
+		return methods
+	case []string:
+		return strings.Join(methods, ", ")
+	case []interface{}:
+		values := make([]string, 0, len(methods))
+		for _, m := range methods {
+			values = append(values, fmt.Sprint(m))
+		}
+		return strings.Join(values, ", ")
+	}
+	return ""
+}
+
+// httpStatus returns the registered http status of the code, or the code
+// itself, falling back to StatusInternalServerError for codes outside the
+// http range.
+func (c Code) httpStatus() int {
+	if info, ok := lookupCode(c); ok && info.HTTPStatus != 0 {
+		return info.HTTPStatus
+	}
+	if c < 100 || c > 599 {
+		return int(StatusInternalServerError)
+	}
+	return int(c)
+}
+
+// RoundTripper is an http.RoundTripper that turns transport failures and non
+// 2xx responses into *Error.
+type RoundTripper struct {
+	// Transport used to perform the requests, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := rt.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, InternalServerFromError(err, UnexpectedMsg)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, FromHTTPResponse(resp)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTripper(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		exp    *Error
+	}{
+		{http.StatusOK, `{}`, nil},
+		{http.StatusNotFound, `{"msg":"no account","error_id":"not_found","status_code":404}`, NotFound("no account")},
+		{http.StatusBadRequest, `{"meta":{"hi":"ho"},"msg":"let's go","status_code":400}`, BadRequest("let's go", SetMeta(Meta{"hi": "ho"}))},
+		{http.StatusBadGateway, `<html></html>`, New(Code(http.StatusBadGateway), "Bad Gateway", SetInternalMeta(Meta{UpstreamResponseKey: Meta{
+			"status":  http.StatusBadGateway,
+			"headers": map[string]string{"Content-Type": "text/html; charset=utf-8", "Date": "Thu, 15 Oct 2026 12:00:00 GMT"},
+			"body":    "<html></html>",
+		}}))},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Date", "Thu, 15 Oct 2026 12:00:00 GMT")
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, tt.body)
+		}))
+
+		client := &http.Client{Transport: &RoundTripper{}}
+		_, err := client.Get(srv.URL)
+		srv.Close()
+
+		var got *Error
+		if err != nil && !errors.As(err, &got) {
+			t.Fatalf("client.Get() unexpected error type %T", err)
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("RoundTrip() status %d\n exp: %v\n got: %v\n", tt.status, tt.exp, got)
+		}
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	type account struct {
+		ID string `json:"id"`
+	}
+
+	tests := []struct {
+		status int
+		body   string
+		into   *account
+		err    *Error
+	}{
+		{http.StatusOK, `{"id":"acc_1"}`, &account{ID: "acc_1"}, nil},
+		{http.StatusNoContent, ``, &account{}, nil},
+		{http.StatusForbidden, `{"msg":"nope","status_code":403}`, &account{}, Forbidden("nope")},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(tt.status)
+		fmt.Fprint(rec, tt.body)
+
+		var got account
+		err := DecodeResponse(rec.Result(), &got)
+		if !reflect.DeepEqual(err, tt.err) {
+			t.Errorf("DecodeResponse(%q) error\n exp: %v\n got: %v\n", tt.body, tt.err, err)
+		}
+		if !reflect.DeepEqual(&got, tt.into) {
+			t.Errorf("DecodeResponse(%q) value\n exp: %v\n got: %v\n", tt.body, tt.into, got)
+		}
+	}
+}
+
+func TestWriteHTTP(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		body   string
+	}{
+		{NotFound("no account"), http.StatusNotFound, `{"msg":"no account","error_id":"not_found","status_code":404}` + "\n"},
+		{New(4, ""), http.StatusInternalServerError, `{"error_id":"Code(4)","status_code":4}` + "\n"},
+		{errors.New("boom"), http.StatusInternalServerError, `{"msg":"unexpected error","error_id":"internal_server","status_code":500}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		WriteHTTP(rec, tt.err)
+
+		if rec.Code != tt.status || rec.Body.String() != tt.body {
+			t.Errorf("WriteHTTP(%v)\n exp: %d %q\n got: %d %q\n", tt.err, tt.status, tt.body, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestWriteHTTPAllow(t *testing.T) {
+	tests := []struct {
+		err error
+		exp string
+	}{
+		{MethodNotAllowed("method not allowed", SetAllow(http.MethodGet, http.MethodHead)), "GET, HEAD"},
+		{MethodNotAllowed("method not allowed", SetMeta(Meta{AllowKey: "POST"})), "POST"},
+		{MethodNotAllowed("method not allowed"), ""},
+		{BadRequest("bad request", SetAllow(http.MethodGet)), ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		WriteHTTP(rec, tt.err)
+
+		if got := rec.Header().Get("Allow"); got != tt.exp {
+			t.Errorf("WriteHTTP(%v) Allow header\n exp: %q\n got: %q\n", tt.err, tt.exp, got)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, MethodNotAllowed("method not allowed", SetAllow(http.MethodGet)))
+	if got := FromHTTPResponse(rec.Result()); got.allow() != "GET" || rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("FromHTTPResponse() allow\n exp: %q\n got: %q (%d)\n", "GET", got.allow(), rec.Code)
+	}
+}
+
+func TestFromHTTPResponseEvidence(t *testing.T) {
+	body := strings.Repeat("x", 2*maxEvidenceBody)
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"X-Request-Id": {"up1"}, "Set-Cookie": {"session=s1"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+
+	exp := Meta{
+		"status":  http.StatusServiceUnavailable,
+		"headers": map[string]string{"X-Request-Id": "up1"},
+		"body":    body[:maxEvidenceBody],
+	}
+	if got := FromHTTPResponse(resp).InternalMeta[UpstreamResponseKey]; !reflect.DeepEqual(got, exp) {
+		t.Errorf("FromHTTPResponse() %s\n exp: %v\n got: %v\n", UpstreamResponseKey, exp, got)
+	}
+}
@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPushbackKey is the trailer metadata key telling grpc clients using
+// the built-in retry policies how long to wait before retrying, in
+// milliseconds.
+const RetryPushbackKey = "grpc-retry-pushback-ms"
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that encodes
+// every error returned by handlers with ToGRPCContext, adding the Meta
+// derived from the request context, the deadline of request_timeout errors,
+// see SetDeadline, and logging them with the Logger, using the configuration
+// of the request context, see WithConfig. The RetryAfter of the errors is
+// sent as the RetryPushbackKey trailer, so grpc retry policies honor it. Errors
+// that are not an *Error are encoded as internal_server errors.
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(errors.UnaryServerInterceptor(
+//		errors.AllowCodes(errors.StatusBadRequest, errors.StatusNotFound),
+//	)))
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = WithStart(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toGRPC(ctx, err, o)
+		}
+		return resp, nil
+	}
+}
+
+// toGRPC encodes err with ToGRPCContext, adding the Meta derived from ctx,
+// applying the options and logging it. grpc errors not wrapping an *Error,
+// e.g. returned by a downstream call, are decoded with FromStatus first, so
+// they go through the options too.
+func toGRPC(ctx context.Context, err error, o options) error {
+	var e *Error
+	for cur, i := err, 0; cur != nil && e == nil && i < maxChainDepth; cur, i = unwrap(cur), i+1 {
+		e, _ = cur.(*Error)
+	}
+	if e == nil {
+		if s, ok := status.FromError(err); ok {
+			e = FromStatus(s)
+		} else {
+			e = BuildError(err)
+		}
+	}
+
+	e = o.boundary(e).withContextMeta(ctx).withDeadline(ctx).withConfig(ctx)
+	logError(e)
+	setRetryPushback(ctx, e)
+	return e.ToGRPCContext(ctx)
+}
+
+// setRetryPushback sets the RetryPushbackKey trailer of the call from the
+// RetryAfter of e, if any and e is retryable, see IsRetryable.
+func setRetryPushback(ctx context.Context, e *Error) {
+	d, ok := e.RetryAfter()
+	if !ok || d < 0 || !IsRetryable(e) {
+		return
+	}
+
+	// it fails only outside a grpc server call
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(RetryPushbackKey, strconv.FormatInt(d.Milliseconds(), 10)))
+}
+
+// GRPCCodeOf returns the grpc code err is encoded with: the code of the
+// grpc status of err, or the code resolved by CodeOf otherwise. It returns
+// codes.OK if err is nil.
+func GRPCCodeOf(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+	return CodeOf(err).grpcCode(loadConfig())
+}
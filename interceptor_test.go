@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCodeOf(t *testing.T) {
+	tests := []struct {
+		err error
+		exp codes.Code
+	}{
+		{nil, codes.OK},
+		{NotFound(""), codes.Code(StatusNotFound)},
+		{status.Error(codes.Unavailable, ""), codes.Unavailable},
+		{fmt.Errorf("get: %w", Forbidden("")), codes.Code(StatusForbidden)},
+		{errors.New("boom"), codes.Code(StatusInternalServerError)},
+	}
+
+	for _, tt := range tests {
+		if got := GRPCCodeOf(tt.err); got != tt.exp {
+			t.Errorf("GRPCCodeOf(%v)\n exp: %d\n got: %d\n", tt.err, tt.exp, got)
+		}
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	err := NotFound("no account", SetMeta(Meta{"account": "a1"}))
+
+	s, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("status.FromError(%v) not recognized", err)
+	}
+
+	if got := FromGRPC(s.Err()); !got.Equal(err) {
+		t.Errorf("FromGRPC(status.FromError(%v))\n%s", err, Diff(err, got))
+	}
+}
+
+// testTransportStream records the trailer set by the interceptors.
+type testTransportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *testTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestRetryPushback(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+
+	tests := []struct {
+		err error
+		exp []string
+	}{
+		{RateLimit("slow down", SetRetryAfter(1500*time.Millisecond)), []string{"1500"}},
+		{NotFound("no account"), nil},
+		{BadRequest("invalid amount", SetRetryAfter(time.Second)), nil},
+	}
+
+	for _, tt := range tests {
+		stream := &testTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+		interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, tt.err
+		})
+
+		if got := stream.trailer.Get(RetryPushbackKey); !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("UnaryServerInterceptor() %s of %v\n exp: %v\n got: %v\n", RetryPushbackKey, tt.err, tt.exp, got)
+		}
+	}
+}
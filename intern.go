@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// maxInterned bounds the number of interned strings, since messages may come
+// from the wire.
+const maxInterned = 4096
+
+// maxInternedLength is the length from which strings are not interned, as
+// long messages are unlikely to repeat.
+const maxInternedLength = 256
+
+var (
+	interned      sync.Map // string -> string
+	internedCount int32
+)
+
+// intern returns a shared copy of s, so identical ids and messages, e.g.
+// decoded from the wire, do not duplicate their bytes on the heap. The ids
+// and default messages of the registered codes are always interned.
+func intern(s string) string {
+	if len(s) == 0 || len(s) > maxInternedLength {
+		return s
+	}
+	if v, ok := interned.Load(s); ok {
+		return v.(string)
+	}
+	if !reserveIntern() {
+		return s
+	}
+
+	v, _ := interned.LoadOrStore(s, s)
+	return v.(string)
+}
+
+// reserveIntern reserves room for an interned string, reporting false once
+// maxInterned is reached. The count never goes past maxInterned, so it can
+// not overflow under sustained unique input.
+func reserveIntern() bool {
+	for {
+		n := atomic.LoadInt32(&internedCount)
+		if n >= maxInterned {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&internedCount, n, n+1) {
+			return true
+		}
+	}
+}
+
+// internInfo interns the id and message of a registered code, regardless of
+// the maxInterned bound.
+func internInfo(info CodeInfo) CodeInfo {
+	if len(info.ID) > 0 {
+		v, _ := interned.LoadOrStore(info.ID, info.ID)
+		info.ID = v.(string)
+	}
+	if len(info.Message) > 0 {
+		v, _ := interned.LoadOrStore(info.Message, info.Message)
+		info.Message = v.(string)
+	}
+	return info
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+// sameData reports whether a and b share their bytes.
+func sameData(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestIntern(t *testing.T) {
+	info, _ := lookupCode(StatusNotFound)
+	msg := strings.Clone(info.Message)
+
+	var decoded Error
+	b, _ := json.Marshal(NotFound(msg))
+	json.Unmarshal(b, &decoded)
+	if !sameData(decoded.Message, info.Message) {
+		t.Errorf("UnmarshalJSON() message not interned as the registered default message")
+	}
+
+	first := FromGRPC(BadRequest(strings.Clone("invalid rut")).ToGRPC())
+	second := FromGRPC(BadRequest(strings.Clone("invalid rut")).ToGRPC())
+	if !sameData(first.Message, second.Message) {
+		t.Errorf("FromGRPC() messages not interned")
+	}
+
+	long := strings.Repeat("x", maxInternedLength+1)
+	if got := intern(long); !sameData(got, long) {
+		t.Errorf("intern() of a long string returned a different copy")
+	}
+}
+
+func TestInternBound(t *testing.T) {
+	count := atomic.LoadInt32(&internedCount)
+	atomic.StoreInt32(&internedCount, maxInterned)
+	defer atomic.StoreInt32(&internedCount, count)
+
+	for i := 0; i < 100; i++ {
+		s := fmt.Sprintf("unique %d", i)
+		if got := intern(s); !sameData(got, s) {
+			t.Fatalf("intern(%q) interned past maxInterned", s)
+		}
+	}
+	if got := atomic.LoadInt32(&internedCount); got != maxInterned {
+		t.Errorf("internedCount past maxInterned\n exp: %d\n got: %d\n", maxInterned, got)
+	}
+}
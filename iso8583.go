@@ -0,0 +1,90 @@
+package errors
+
+// ResponseCodeKey is the Meta key holding the ISO 8583 response code of a
+// declined card transaction, see FromISO8583.
+const ResponseCodeKey = "response_code"
+
+// cardResponse is the mapping of an ISO 8583 response code.
+type cardResponse struct {
+	code Code
+	msg  string
+}
+
+// cardResponses maps the common ISO 8583 response codes of the card
+// networks into the errors taxonomy.
+var cardResponses = map[string]cardResponse{
+	"03": {StatusBadRequest, "invalid merchant"},
+	"04": {StatusForbidden, "pick up card"},
+	"05": {StatusPaymentRequired, "do not honor"},
+	"12": {StatusBadRequest, "invalid transaction"},
+	"13": {StatusUnprocessableEntity, "invalid amount"},
+	"14": {StatusUnprocessableEntity, "invalid card number"},
+	"30": {StatusBadRequest, "format error"},
+	"41": {StatusForbidden, "lost card"},
+	"43": {StatusForbidden, "stolen card"},
+	"51": {StatusPaymentRequired, "insufficient funds"},
+	"54": {StatusPaymentRequired, "expired card"},
+	"55": {StatusUnauthorized, "incorrect pin"},
+	"57": {StatusForbidden, "transaction not permitted to cardholder"},
+	"58": {StatusForbidden, "transaction not permitted to terminal"},
+	"61": {StatusPaymentRequired, "exceeds withdrawal amount limit"},
+	"62": {StatusForbidden, "restricted card"},
+	"65": {StatusTooManyRequests, "exceeds withdrawal frequency limit"},
+	"68": {StatusRequestTimeout, "response received too late"},
+	"75": {StatusUnauthorized, "pin tries exceeded"},
+	"91": {StatusInternalServerError, "issuer unavailable"},
+	"96": {StatusInternalServerError, "system malfunction"},
+}
+
+// isoResponseCodes are the response codes of the errors that were not
+// created by FromISO8583, by code.
+var isoResponseCodes = map[Code]string{
+	StatusBadRequest:          "12",
+	StatusUnauthorized:        "55",
+	StatusPaymentRequired:     "05",
+	StatusForbidden:           "57",
+	StatusRequestTimeout:      "68",
+	StatusUnprocessableEntity: "30",
+	StatusTooManyRequests:     "65",
+	StatusInternalServerError: "96",
+}
+
+// FromISO8583 returns the Error of a card transaction declined with the
+// given ISO 8583 response code, kept in Meta under ResponseCodeKey. It
+// returns nil for the approval codes 00, 08, 10 and 11, and
+// delinquent errors for unknown codes.
+//
+//	if err := errors.FromISO8583(resp.ResponseCode); err != nil {
+//		return err
+//	}
+func FromISO8583(responseCode string) *Error {
+	switch responseCode {
+	case "00", "08", "10", "11":
+		return nil
+	}
+
+	r, ok := cardResponses[responseCode]
+	if !ok {
+		r = cardResponse{StatusPaymentRequired, "card declined"}
+	}
+	return New(r.code, r.msg, SetMeta(Meta{ResponseCodeKey: responseCode}))
+}
+
+// ISO8583Of returns the ISO 8583 response code of err: the one it was
+// created from by FromISO8583, or the one matching its code, e.g. 05 (do not
+// honor) for delinquent errors. It returns 00 (approved) if err is nil and 96
+// (system malfunction) for codes without a matching response code.
+func ISO8583Of(err error) string {
+	if err == nil {
+		return "00"
+	}
+
+	e := BuildError(err)
+	if responseCode, ok := e.Meta[ResponseCodeKey].(string); ok && len(responseCode) > 0 {
+		return responseCode
+	}
+	if responseCode, ok := isoResponseCodes[e.StatusCode]; ok {
+		return responseCode
+	}
+	return "96"
+}
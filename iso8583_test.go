@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFromISO8583(t *testing.T) {
+	tests := []struct {
+		responseCode string
+		exp          *Error
+	}{
+		{"00", nil},
+		{"10", nil},
+		{"05", Delinquent("do not honor", SetMeta(Meta{ResponseCodeKey: "05"}))},
+		{"51", Delinquent("insufficient funds", SetMeta(Meta{ResponseCodeKey: "51"}))},
+		{"91", InternalServer("issuer unavailable", SetMeta(Meta{ResponseCodeKey: "91"}))},
+		{"N7", Delinquent("card declined", SetMeta(Meta{ResponseCodeKey: "N7"}))},
+	}
+
+	for _, tt := range tests {
+		got := FromISO8583(tt.responseCode)
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("FromISO8583(%q)\n exp: %v\n got: %v\n", tt.responseCode, tt.exp, got)
+		}
+		if exp := tt.responseCode; got != nil && ISO8583Of(got) != exp {
+			t.Errorf("ISO8583Of(%v)\n exp: %s\n got: %s\n", got, exp, ISO8583Of(got))
+		}
+	}
+}
+
+func TestISO8583Of(t *testing.T) {
+	tests := []struct {
+		err error
+		exp string
+	}{
+		{nil, "00"},
+		{Delinquent("no funds"), "05"},
+		{RateLimit("slow down"), "65"},
+		{NotFound("no account"), "96"},
+		{errors.New("db down"), "96"},
+	}
+
+	for _, tt := range tests {
+		if got := ISO8583Of(tt.err); got != tt.exp {
+			t.Errorf("ISO8583Of(%v)\n exp: %s\n got: %s\n", tt.err, tt.exp, got)
+		}
+	}
+}
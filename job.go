@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"math"
+	"time"
+)
+
+// Meta keys of the errors of background jobs, see SetAttempt.
+const (
+	AttemptKey     = "attempt"       // attempt that failed, starting at 1
+	MaxAttemptsKey = "max_attempts"  // attempts allowed by the retry policy
+	NextRetryAtKey = "next_retry_at" // time of the next attempt, RFC 3339
+)
+
+// RetryPolicy is the retry policy of a background job. The backoff before
+// the attempt n+1 is InitialBackoff * Multiplier^(n-1), up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int           // attempts allowed, unlimited if 0
+	InitialBackoff time.Duration // 1s if 0
+	MaxBackoff     time.Duration // unbounded if 0
+	Multiplier     float64       // 2 if 0
+}
+
+// Backoff returns the time to wait after the given failed attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	initial, multiplier := p.InitialBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = time.Second
+	}
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	backoff := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	if backoff > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(backoff)
+}
+
+// JobRetry is the retry schedule of a failed background job.
+type JobRetry struct {
+	Attempt     int
+	MaxAttempts int
+	NextRetryAt time.Time // zero if the job must not be retried
+}
+
+// SetAttempt sets the failed attempt of a background job and its retry
+// schedule into the Meta of the error under the well-known keys. The next
+// retry is computed from the policy, delayed up to the RetryAfter of the
+// error, and is not set for errors that are not retryable or once the
+// attempts are exhausted.
+//
+//	errors.InternalServerFromError(err, "sync failed", errors.SetAttempt(job.Attempt, policy))
+func SetAttempt(attempt int, p RetryPolicy) errorParamsSetter {
+	return func(e *Error) {
+		m := Meta{AttemptKey: attempt}
+		if p.MaxAttempts > 0 {
+			m[MaxAttemptsKey] = p.MaxAttempts
+		}
+
+		if retryable(e) && (p.MaxAttempts <= 0 || attempt < p.MaxAttempts) {
+			backoff := p.Backoff(attempt)
+			if d, ok := e.RetryAfter(); ok && d > backoff {
+				backoff = d
+			}
+			m[NextRetryAtKey] = now().Add(backoff).UTC().Format(time.RFC3339)
+		}
+		SetMeta(m)(e)
+	}
+}
+
+// retryable reports whether the error may be retried, as set under
+// RetryableKey or by its code.
+func retryable(e *Error) bool {
+	if retry, ok := e.Meta[RetryableKey].(bool); ok {
+		return retry
+	}
+	return IsRetryable(e)
+}
+
+// JobRetry returns the retry schedule set with SetAttempt, also when the
+// error was decoded from a queue payload. It reports false if there is none.
+func (e *Error) JobRetry() (JobRetry, bool) {
+	attempt, ok := metaInt(e.Meta[AttemptKey])
+	if !ok {
+		return JobRetry{}, false
+	}
+
+	r := JobRetry{Attempt: attempt}
+	r.MaxAttempts, _ = metaInt(e.Meta[MaxAttemptsKey])
+
+	switch v := e.Meta[NextRetryAtKey].(type) {
+	case time.Time:
+		r.NextRetryAt = v
+	case string:
+		r.NextRetryAt, _ = time.Parse(time.RFC3339, v)
+	}
+
+	return r, true
+}
+
+// Requeue returns when the failed job must be retried according to the
+// schedule set with SetAttempt, reporting false if it must not, e.g. to move
+// it to the dead letter queue.
+//
+//	if at, ok := errors.FromGRPC(err).Requeue(); ok {
+//		queue.PublishAt(job, at)
+//	}
+func (e *Error) Requeue() (time.Time, bool) {
+	r, ok := e.JobRetry()
+	if !ok || r.NextRetryAt.IsZero() {
+		return time.Time{}, false
+	}
+	return r.NextRetryAt, true
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		policy  RetryPolicy
+		attempt int
+		exp     time.Duration
+	}{
+		{RetryPolicy{}, 1, time.Second},
+		{RetryPolicy{}, 4, 8 * time.Second},
+		{RetryPolicy{InitialBackoff: time.Minute, Multiplier: 3}, 3, 9 * time.Minute},
+		{RetryPolicy{MaxBackoff: 10 * time.Second}, 10, 10 * time.Second},
+		{RetryPolicy{}, 100, time.Duration(1<<63 - 1)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.Backoff(tt.attempt); got != tt.exp {
+			t.Errorf("(%+v).Backoff(%d)\n exp: %v\n got: %v\n", tt.policy, tt.attempt, tt.exp, got)
+		}
+	}
+}
+
+func TestSetAttempt(t *testing.T) {
+	current := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Second}
+
+	tests := []struct {
+		err *Error
+		exp JobRetry
+		ok  bool
+	}{
+		{InternalServer("sync failed", SetAttempt(1, policy)), JobRetry{1, 3, current.Add(10 * time.Second)}, true},
+		{InternalServer("sync failed", SetAttempt(2, policy)), JobRetry{2, 3, current.Add(20 * time.Second)}, true},
+		{InternalServer("sync failed", SetAttempt(3, policy)), JobRetry{3, 3, time.Time{}}, false},
+		{RateLimit("slow down", SetRetryAfter(time.Minute), SetAttempt(1, policy)), JobRetry{1, 3, current.Add(time.Minute)}, true},
+		{BadRequest("invalid job", SetAttempt(1, policy)), JobRetry{1, 3, time.Time{}}, false},
+	}
+
+	for _, tt := range tests {
+		var decoded *Error
+		b, _ := json.Marshal(tt.err)
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal(%s) unexpected error: %v", b, err)
+		}
+
+		if got, _ := decoded.JobRetry(); got != tt.exp {
+			t.Errorf("(%v).JobRetry()\n exp: %+v\n got: %+v\n", tt.err, tt.exp, got)
+		}
+		if at, ok := decoded.Requeue(); ok != tt.ok || !at.Equal(tt.exp.NextRetryAt) {
+			t.Errorf("(%v).Requeue()\n exp: %v %t\n got: %v %t\n", tt.err, tt.exp.NextRetryAt, tt.ok, at, ok)
+		}
+	}
+
+	if _, ok := BadRequest("no job").JobRetry(); ok {
+		t.Errorf("JobRetry() without attempt\n exp: false\n got: true\n")
+	}
+}
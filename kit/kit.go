@@ -0,0 +1,33 @@
+// Package kit provides go-kit transport error encoders that emit errors in
+// the standard format.
+package kit
+
+import (
+	"context"
+	"net/http"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"google.golang.org/grpc"
+
+	"github.com/Finciero/errors"
+)
+
+var _ httptransport.ErrorEncoder = ErrorEncoder
+
+// ErrorEncoder is an httptransport.ErrorEncoder writing err with
+// errors.WriteHTTP.
+//
+//	httptransport.NewServer(e, dec, enc, httptransport.ServerErrorEncoder(kit.ErrorEncoder))
+func ErrorEncoder(_ context.Context, err error, w http.ResponseWriter) {
+	errors.WriteHTTP(w, err)
+}
+
+// GRPCErrorEncoder returns a grpc.ServerOption that encodes the errors
+// returned by go-kit grpc transport servers with ToGRPC. It is chained after
+// the interceptors of the server, so it may be combined with
+// grpc.UnaryInterceptor.
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(auth), kit.GRPCErrorEncoder())
+func GRPCErrorEncoder(opts ...errors.Option) grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(errors.UnaryServerInterceptor(opts...))
+}
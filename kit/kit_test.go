@@ -0,0 +1,62 @@
+package kit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Finciero/errors"
+)
+
+func TestErrorEncoder(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ErrorEncoder(context.Background(), errors.NotFound("no account"), rec)
+
+	if got := errors.FromHTTPResponse(rec.Result()); rec.Code != http.StatusNotFound || got.StatusCode != errors.StatusNotFound || got.Message != "no account" {
+		t.Errorf("ErrorEncoder()\n exp: %v\n got: %d %v\n", errors.NotFound("no account"), rec.Code, got)
+	}
+}
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (healthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, errors.Forbidden("no access")
+}
+
+func TestGRPCErrorEncoder(t *testing.T) {
+	var intercepted bool
+	auth := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		intercepted = true
+		return handler(ctx, req)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(auth), GRPCErrorEncoder(errors.AllowCodes(errors.StatusNotFound)))
+	grpc_health_v1.RegisterHealthServer(srv, healthServer{})
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if got := errors.FromGRPC(err); !intercepted || got.StatusCode != errors.StatusInternalServerError {
+		t.Errorf("GRPCErrorEncoder() with AllowCodes\n exp: intercepted %d\n got: intercepted %t %v\n", errors.StatusInternalServerError, intercepted, got)
+	}
+}
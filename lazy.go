@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// lazyValue is a meta value computed the first time it is serialized or
+// formatted.
+type lazyValue struct {
+	once sync.Once
+	fn   func() interface{}
+	v    interface{}
+}
+
+func (l *lazyValue) value() interface{} {
+	l.once.Do(func() {
+		l.v = l.fn()
+	})
+	return l.v
+}
+
+func (l *lazyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(metaValue(l.value()))
+}
+
+func (l *lazyValue) String() string {
+	return fmt.Sprint(l.value())
+}
+
+// SetMetaLazy sets into the Meta of the error a value computed by fn only
+// if, and the first time, the error is serialized or formatted. Useful for
+// expensive debug payloads of errors that are usually handled and dropped.
+//
+//	errors.InternalServerFromError(err, "", errors.SetMetaLazy("request_dump", func() interface{} {
+//		dump, _ := httputil.DumpRequest(r, true)
+//		return string(dump)
+//	}))
+func SetMetaLazy(key string, fn func() interface{}) errorParamsSetter {
+	return SetMeta(Meta{key: &lazyValue{fn: fn}})
+}
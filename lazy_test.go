@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSetMetaLazy(t *testing.T) {
+	calls := 0
+	err := BadRequest("", SetMetaLazy("dump", func() interface{} {
+		calls++
+		return "GET /accounts"
+	}))
+
+	if calls != 0 {
+		t.Fatalf("SetMetaLazy() called the provider on construction")
+	}
+
+	if exp, got := `status_code=400 error_id="bad_request" dump="GET /accounts"`, err.Error(); got != exp {
+		t.Errorf("Error()\n exp: %s\n got: %s\n", exp, got)
+	}
+
+	buff, _ := json.Marshal(err)
+	if exp := `{"meta":{"dump":"GET /accounts"},"error_id":"bad_request","status_code":400}`; string(buff) != exp {
+		t.Errorf("json.Marshal()\n exp: %s\n got: %s\n", exp, buff)
+	}
+
+	if calls != 1 {
+		t.Errorf("SetMetaLazy() provider calls\n exp: 1\n got: %d\n", calls)
+	}
+}
@@ -0,0 +1,42 @@
+package errors
+
+import "unicode/utf8"
+
+// MessageLengthKey is the InternalMeta key holding the original length in
+// bytes of a truncated Message.
+const MessageLengthKey = "message_length"
+
+// ellipsis is appended to truncated messages.
+const ellipsis = "…"
+
+// SetMaxMessageLength limits the length in bytes of the Message of the errors
+// created by this package constructors. Longer messages are truncated on a
+// rune boundary and end with an ellipsis, unless the limit is shorter than
+// the ellipsis, and their original length is set into the InternalMeta.
+// Zero, the default, disables the limit. Messages decoded by FromGRPC,
+// UnmarshalJSON and the other decoders are kept as received.
+func SetMaxMessageLength(n int) {
+	UpdateConfig(func(c *Config) { c.MaxMessageLength = n })
+}
+
+// truncateMessage truncates the Message of the error to the configured
+// limit.
+func (e *Error) truncateMessage() {
+	max := e.cfg().MaxMessageLength
+	if max <= 0 || len(e.Message) <= max {
+		return
+	}
+
+	suffix := ellipsis
+	if max < len(ellipsis) {
+		suffix = ""
+	}
+
+	n := max - len(suffix)
+	for n > 0 && !utf8.RuneStart(e.Message[n]) {
+		n--
+	}
+
+	SetInternalMeta(Meta{MessageLengthKey: len(e.Message)})(e)
+	e.Message = e.Message[:n] + suffix
+}
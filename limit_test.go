@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaxMessageLength(t *testing.T) {
+	SetMaxMessageLength(10)
+	defer SetMaxMessageLength(0)
+
+	tests := []struct {
+		msg    string
+		exp    string
+		length interface{}
+	}{
+		{"short", "short", nil},
+		{"exactly 10", "exactly 10", nil},
+		{"select * from accounts", "select …", 22},
+		{"añañañañañ", "añaña…", 15},
+		{strings.Repeat("ñ", 20), "ñññ…", 40},
+	}
+
+	for _, tt := range tests {
+		e := InternalServer(tt.msg)
+		if e.Message != tt.exp {
+			t.Errorf("InternalServer(%q).Message\n exp: %q\n got: %q\n", tt.msg, tt.exp, e.Message)
+		}
+		if got := e.InternalMeta[MessageLengthKey]; got != tt.length {
+			t.Errorf("InternalServer(%q).InternalMeta[%q]\n exp: %v\n got: %v\n", tt.msg, MessageLengthKey, tt.length, got)
+		}
+	}
+
+	for max, exp := range map[int]string{1: "a", 2: "a", 3: "…", 4: "a…"} {
+		SetMaxMessageLength(max)
+		if got := InternalServer("añañ").Message; got != exp {
+			t.Errorf("InternalServer() with limit %d\n exp: %q\n got: %q\n", max, exp, got)
+		}
+	}
+}
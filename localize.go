@@ -0,0 +1,262 @@
+package errors
+
+import (
+	"bytes"
+	"sync"
+	"text/template"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is used when none of the requested languages has a
+// registered translation.
+var DefaultLanguage = language.English
+
+// CountKey is the Meta key used to select the plural form of a translation.
+const CountKey = "count"
+
+// translation holds the message templates of each plural form.
+type translation map[plural.Form]*template.Template
+
+// MissingTranslation is the policy of Localize for errors without a
+// translation in any language of the fallback chain.
+type MissingTranslation int
+
+// Missing translation policies. The UserMessage set by the policies is
+// never overridden.
+const (
+	// KeepUserMessage leaves the error unchanged.
+	KeepUserMessage MissingTranslation = iota
+	// UseCodeMessage sets the registered message of the code, e.g. "not
+	// found", as UserMessage.
+	UseCodeMessage
+	// UseErrorID sets the error id, e.g. "not_found", as UserMessage.
+	UseErrorID
+)
+
+var translations = struct {
+	sync.RWMutex
+	tags    []language.Tag
+	msgs    map[language.Tag]map[string]translation
+	matcher language.Matcher
+}{
+	msgs: map[language.Tag]map[string]translation{},
+}
+
+// SetLocaleFallback sets the languages whose translations Localize tries, in
+// order, when there is none for an error in the given language. By default
+// the parent languages are tried, e.g. es for es-CL, followed by
+// DefaultLanguage.
+//
+//	errors.SetLocaleFallback(language.MustParse("es-CL"), language.Spanish, language.English)
+func SetLocaleFallback(tag language.Tag, fallbacks ...language.Tag) {
+	UpdateConfig(func(c *Config) {
+		if c.LocaleFallbacks == nil {
+			c.LocaleFallbacks = map[language.Tag][]language.Tag{}
+		}
+		c.LocaleFallbacks[tag] = append([]language.Tag(nil), fallbacks...)
+	})
+}
+
+// SetMissingTranslation sets the policy of Localize for errors without a
+// translation, KeepUserMessage by default.
+func SetMissingTranslation(policy MissingTranslation) {
+	UpdateConfig(func(c *Config) { c.MissingTranslation = policy })
+}
+
+// RegisterTranslation registers the user message for the given error_id in
+// the given language. The message is a text/template executed with the error
+// Meta as data, e.g. "Cuenta {{.account}} no encontrada". See templateFuncs
+// for the available functions.
+func RegisterTranslation(tag language.Tag, id, msg string) error {
+	return RegisterPluralTranslation(tag, id, map[plural.Form]string{plural.Other: msg})
+}
+
+// RegisterPluralTranslation registers the user message for the given error_id
+// in the given language, with one message per CLDR plural form. The form is
+// selected by the Meta CountKey value, plural.Other being used when it is
+// missing or there is no message for the form.
+//
+//	errors.RegisterPluralTranslation(language.Spanish, "transfers_failed", map[plural.Form]string{
+//		plural.One:   "{{.count}} transferencia falló",
+//		plural.Other: "{{.count}} transferencias fallaron",
+//	})
+func RegisterPluralTranslation(tag language.Tag, id string, forms map[plural.Form]string) error {
+	t, err := newTranslation(tag, id, forms)
+	if err != nil {
+		return err
+	}
+
+	translations.Lock()
+	setTranslation(tag, id, t)
+	translations.Unlock()
+
+	return nil
+}
+
+// newTranslation returns the translation of the given plural forms.
+func newTranslation(tag language.Tag, id string, forms map[plural.Form]string) (translation, error) {
+	t := translation{}
+	for form, msg := range forms {
+		if msg == "" {
+			continue
+		}
+
+		tpl, err := template.New(id).Funcs(templateFuncs(tag)).Option("missingkey=zero").Parse(msg)
+		if err != nil {
+			return nil, err
+		}
+		t[form] = tpl
+	}
+	return t, nil
+}
+
+// setTranslation registers t for the given error_id and language. It must be
+// called with translations locked.
+func setTranslation(tag language.Tag, id string, t translation) {
+	if _, ok := translations.msgs[tag]; !ok {
+		translations.msgs[tag] = map[string]translation{}
+		translations.tags = append(translations.tags, tag)
+		translations.matcher = language.NewMatcher(translations.tags)
+	}
+	translations.msgs[tag][id] = t
+}
+
+// deleteTranslation unregisters the translation of the given error_id and
+// language, and the language once it has none. It must be called with
+// translations locked.
+func deleteTranslation(tag language.Tag, id string) {
+	msgs, ok := translations.msgs[tag]
+	if !ok {
+		return
+	}
+	delete(msgs, id)
+	if len(msgs) > 0 {
+		return
+	}
+
+	delete(translations.msgs, tag)
+	tags := translations.tags[:0]
+	for _, t := range translations.tags {
+		if t != tag {
+			tags = append(tags, t)
+		}
+	}
+	translations.tags = tags
+	translations.matcher = nil
+	if len(tags) > 0 {
+		translations.matcher = language.NewMatcher(tags)
+	}
+}
+
+// Localize returns a copy of the error with UserMessage translated to the
+// registered language that best matches the given ones, in order of
+// preference, or to the first language of its fallback chain with a
+// translation, see SetLocaleFallback. Errors without translation are handled
+// according to the SetMissingTranslation policy.
+func (e *Error) Localize(tags ...language.Tag) *Error {
+	translations.RLock()
+	defer translations.RUnlock()
+
+	tag := DefaultLanguage
+	if translations.matcher != nil && len(tags) > 0 {
+		if _, index, confidence := translations.matcher.Match(tags...); confidence != language.No {
+			tag = translations.tags[index]
+		}
+	}
+
+	cfg := e.cfg()
+	for _, candidate := range localeChain(tag, cfg.LocaleFallbacks) {
+		if msg, ok := e.translate(candidate); ok {
+			localized := *e
+			localized.UserMessage = msg
+			localized.locale = candidate.String()
+			return &localized
+		}
+	}
+	return e.missingTranslation(cfg.MissingTranslation)
+}
+
+// translate returns the UserMessage of the error in the given language. It
+// must be called with translations locked.
+func (e *Error) translate(tag language.Tag) (string, bool) {
+	t, ok := translations.msgs[tag][e.ErrorID()]
+	if !ok {
+		return "", false
+	}
+
+	tpl, ok := t[pluralForm(tag, e.Meta[CountKey])]
+	if !ok {
+		if tpl, ok = t[plural.Other]; !ok {
+			return "", false
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, map[string]interface{}(e.Meta)); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// localeChain returns the languages tried by Localize for tag, in order,
+// given the configured fallbacks.
+func localeChain(tag language.Tag, fallbacks map[language.Tag][]language.Tag) []language.Tag {
+	chain := []language.Tag{tag}
+	if fallbacks, ok := fallbacks[tag]; ok {
+		return append(chain, fallbacks...)
+	}
+
+	for parent := tag.Parent(); parent != language.Und; parent = parent.Parent() {
+		chain = append(chain, parent)
+	}
+	if tag != DefaultLanguage {
+		chain = append(chain, DefaultLanguage)
+	}
+	return chain
+}
+
+// missingTranslation returns the error localized according to the missing
+// translation policy.
+func (e *Error) missingTranslation(policy MissingTranslation) *Error {
+	if len(e.UserMessage) > 0 {
+		return e
+	}
+
+	var msg string
+	switch policy {
+	case UseCodeMessage:
+		if info, ok := lookupCode(e.StatusCode); ok {
+			msg = info.Message
+		}
+	case UseErrorID:
+		msg = e.ErrorID()
+	}
+	if len(msg) == 0 {
+		return e
+	}
+
+	localized := *e
+	localized.UserMessage = msg
+	return &localized
+}
+
+// Locale returns the language of the UserMessage set by Localize, or
+// decoded from a grpc errdetails.LocalizedMessage. It is empty if unknown.
+func (e *Error) Locale() string {
+	return e.locale
+}
+
+// pluralForm returns the CLDR plural form of count in the given language.
+func pluralForm(tag language.Tag, count interface{}) plural.Form {
+	n, ok := toInt64(count)
+	if !ok {
+		return plural.Other
+	}
+
+	if n < 0 {
+		n = -n
+	}
+	return plural.Cardinal.MatchPlural(tag, int(n), 0, 0, 0, 0)
+}
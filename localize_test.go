@@ -0,0 +1,142 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+func TestWriteHTTPRequestLocalized(t *testing.T) {
+	RegisterTranslation(language.English, "not_found", "Account {{.account}} not found")
+	RegisterTranslation(language.Spanish, "not_found", "Cuenta {{.account}} no encontrada")
+
+	tests := []struct {
+		accept string
+		exp    string
+	}{
+		{"", "Account 42 not found"},
+		{"es-CL,es;q=0.9,en;q=0.8", "Cuenta 42 no encontrada"},
+		{"en;q=0.5,es;q=0.9", "Cuenta 42 no encontrada"},
+		{"fr", "Account 42 not found"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", tt.accept)
+		rec := httptest.NewRecorder()
+
+		WriteHTTPRequest(rec, r, NotFound("no account", SetMeta(Meta{"account": 42})))
+
+		got := FromHTTPResponse(rec.Result())
+		if got.UserMessage != tt.exp {
+			t.Errorf("WriteHTTPRequest(Accept-Language: %q) user_msg\n exp: %q\n got: %q\n", tt.accept, tt.exp, got.UserMessage)
+		}
+	}
+}
+
+func TestLocalizePlural(t *testing.T) {
+	RegisterPluralTranslation(language.Spanish, "invalid_params", map[plural.Form]string{
+		plural.One:   "{{.count}} transferencia falló",
+		plural.Other: "{{.count}} transferencias fallaron",
+	})
+	RegisterPluralTranslation(language.English, "invalid_params", map[plural.Form]string{
+		plural.One:   "{{.count}} transfer failed",
+		plural.Other: "{{.count}} transfers failed",
+	})
+
+	tests := []struct {
+		tag   language.Tag
+		count interface{}
+		exp   string
+	}{
+		{language.Spanish, 1, "1 transferencia falló"},
+		{language.Spanish, 3, "3 transferencias fallaron"},
+		{language.Spanish, float64(1), "1 transferencia falló"},
+		{language.English, 0, "0 transfers failed"},
+		{language.English, 1, "1 transfer failed"},
+	}
+
+	for _, tt := range tests {
+		got := InvalidParams("", SetMeta(Meta{"count": tt.count})).Localize(tt.tag).UserMessage
+		if got != tt.exp {
+			t.Errorf("Localize(%v) count=%v\n exp: %q\n got: %q\n", tt.tag, tt.count, tt.exp, got)
+		}
+	}
+}
+
+func TestLocalizeMoney(t *testing.T) {
+	RegisterTranslation(language.MustParse("es-CL"), "delinquent", "Saldo insuficiente: faltan {{money .missing .currency}}")
+	RegisterTranslation(language.English, "delinquent", "Insufficient funds: {{money .missing .currency}} missing")
+
+	tests := []struct {
+		tag      language.Tag
+		missing  interface{}
+		currency string
+		exp      string
+	}{
+		{language.MustParse("es-CL"), 12500, "CLP", "Saldo insuficiente: faltan CLP 12.500"},
+		{language.MustParse("es-CL"), float64(1250), "USD", "Saldo insuficiente: faltan USD 12,50"},
+		{language.English, 12500, "CLP", "Insufficient funds: CLP 12,500 missing"},
+	}
+
+	for _, tt := range tests {
+		err := Delinquent("", SetMeta(Meta{"missing": tt.missing, "currency": tt.currency}))
+		got := err.Localize(tt.tag).UserMessage
+		if got != tt.exp {
+			t.Errorf("Localize(%v)\n exp: %q\n got: %q\n", tt.tag, tt.exp, got)
+		}
+	}
+}
+
+func TestLocalizeFallback(t *testing.T) {
+	const transferFailed Code = 1101
+	if err := RegisterCode(CodeInfo{Code: transferFailed, ID: "transfer_failed", HTTPStatus: http.StatusConflict, Message: "transfer failed"}); err != nil {
+		t.Fatalf("RegisterCode() unexpected error: %v", err)
+	}
+	defer func() {
+		delete(registry.codes, transferFailed)
+		ids.Delete(transferFailed)
+	}()
+
+	chile := language.MustParse("es-CL")
+	RegisterTranslation(chile, "rate_limit", "Calma po")
+	RegisterTranslation(language.Spanish, "bad_request", "Solicitud inválida")
+	RegisterTranslation(language.English, "forbidden", "Forbidden")
+	defer SetConfig(CurrentConfig())
+
+	tests := []struct {
+		policy MissingTranslation
+		err    *Error
+		exp    string
+	}{
+		{KeepUserMessage, RateLimit("slow down"), "Calma po"},
+		{KeepUserMessage, BadRequest("invalid"), "Solicitud inválida"},
+		{KeepUserMessage, Forbidden("forbidden"), "Forbidden"},
+		{KeepUserMessage, New(transferFailed, "transfer failed"), ""},
+		{UseCodeMessage, New(transferFailed, "transfer failed"), "transfer failed"},
+		{UseErrorID, New(transferFailed, "transfer failed"), "transfer_failed"},
+		{UseErrorID, New(transferFailed, "transfer failed", func(e *Error) { e.UserMessage = "Try later" }), "Try later"},
+	}
+
+	for _, tt := range tests {
+		SetMissingTranslation(tt.policy)
+		if got := tt.err.Localize(chile).UserMessage; got != tt.exp {
+			t.Errorf("Localize(es-CL) of %v with policy %d\n exp: %q\n got: %q\n", tt.err, tt.policy, tt.exp, got)
+		}
+	}
+
+	SetMissingTranslation(KeepUserMessage)
+	SetLocaleFallback(chile)
+	if got := BadRequest("invalid").Localize(chile).UserMessage; got != "" {
+		t.Errorf("Localize(es-CL) without fallbacks\n exp: %q\n got: %q\n", "", got)
+	}
+
+	ctx := WithConfig(context.Background(), func(c *Config) { c.MissingTranslation = UseErrorID })
+	if got := New(transferFailed, "transfer failed").withConfig(ctx).Localize(chile).UserMessage; got != "transfer_failed" {
+		t.Errorf("Localize(es-CL) with a context policy\n exp: %q\n got: %q\n", "transfer_failed", got)
+	}
+}
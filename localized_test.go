@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestLocalizedMessageDetail(t *testing.T) {
+	RegisterTranslation(language.Spanish, "forbidden", "No tienes acceso")
+
+	localized := Forbidden("no access").Localize(language.Spanish)
+	if localized.Locale() != "es" {
+		t.Fatalf("Localize().Locale()\n exp: es\n got: %q\n", localized.Locale())
+	}
+
+	var detail *errdetails.LocalizedMessage
+	for _, d := range localized.GRPCStatus().Details() {
+		if d, ok := d.(*errdetails.LocalizedMessage); ok {
+			detail = d
+		}
+	}
+	if detail == nil || detail.Locale != "es" || detail.Message != "No tienes acceso" {
+		t.Errorf("GRPCStatus() LocalizedMessage\n exp: es No tienes acceso\n got: %v\n", detail)
+	}
+
+	tests := []struct {
+		err    *Error
+		msg    string
+		locale string
+	}{
+		{localized, "No tienes acceso", "es"},
+		{&Error{StatusCode: StatusNotFound, UserMessage: "Not found"}, "Not found", "en"},
+		{NotFound("no account"), "", ""},
+	}
+
+	for _, tt := range tests {
+		got := FromGRPC(tt.err.ToGRPC())
+		if got.UserMessage != tt.msg || got.Locale() != tt.locale {
+			t.Errorf("FromGRPC(ToGRPC()) user message\n exp: %q %q\n got: %q %q\n", tt.locale, tt.msg, got.Locale(), got.UserMessage)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package logadapters provides errors.Logger implementations for common
+// loggers.
+//
+//	errors.SetLogger(logadapters.Slog(slog.Default()))
+package logadapters
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+
+	"github.com/Finciero/errors"
+)
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// Slog returns an errors.Logger logging with l, with the fields as
+// attributes.
+func Slog(l *slog.Logger) errors.Logger {
+	return slogLogger{l}
+}
+
+func (s slogLogger) Debug(msg string, fields ...errors.Field) { s.log(slog.LevelDebug, msg, fields) }
+func (s slogLogger) Info(msg string, fields ...errors.Field)  { s.log(slog.LevelInfo, msg, fields) }
+func (s slogLogger) Error(msg string, fields ...errors.Field) { s.log(slog.LevelError, msg, fields) }
+
+func (s slogLogger) log(level slog.Level, msg string, fields []errors.Field) {
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.Any(f.Key, f.Value)
+	}
+	s.l.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+type zapLogger struct {
+	l *zap.Logger
+}
+
+// Zap returns an errors.Logger logging with l, with the fields as zap
+// fields.
+func Zap(l *zap.Logger) errors.Logger {
+	return zapLogger{l}
+}
+
+func (z zapLogger) Debug(msg string, fields ...errors.Field) { z.l.Debug(msg, zapFields(fields)...) }
+func (z zapLogger) Info(msg string, fields ...errors.Field)  { z.l.Info(msg, zapFields(fields)...) }
+func (z zapLogger) Error(msg string, fields ...errors.Field) { z.l.Error(msg, zapFields(fields)...) }
+
+func zapFields(fields []errors.Field) []zap.Field {
+	zfields := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		zfields[i] = zap.Any(f.Key, f.Value)
+	}
+	return zfields
+}
+
+type logrusLogger struct {
+	l logrus.FieldLogger
+}
+
+// Logrus returns an errors.Logger logging with l, with the fields as logrus
+// fields.
+func Logrus(l logrus.FieldLogger) errors.Logger {
+	return logrusLogger{l}
+}
+
+func (l logrusLogger) Debug(msg string, fields ...errors.Field) { l.with(fields).Debug(msg) }
+func (l logrusLogger) Info(msg string, fields ...errors.Field)  { l.with(fields).Info(msg) }
+func (l logrusLogger) Error(msg string, fields ...errors.Field) { l.with(fields).Error(msg) }
+
+func (l logrusLogger) with(fields []errors.Field) logrus.FieldLogger {
+	lfields := make(logrus.Fields, len(fields))
+	for _, f := range fields {
+		lfields[f.Key] = f.Value
+	}
+	return l.l.WithFields(lfields)
+}
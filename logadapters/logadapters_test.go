@@ -0,0 +1,68 @@
+package logadapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/Finciero/errors"
+)
+
+func TestAdapters(t *testing.T) {
+	fields := errors.NotFound("no account", errors.SetMeta(errors.Meta{"account": "a1"})).LogFields()
+	exp := map[string]interface{}{
+		"msg":         "no account",
+		"status_code": float64(404),
+		"error_id":    "not_found",
+		"account":     "a1",
+	}
+
+	var slogBuf bytes.Buffer
+	Slog(slog.New(slog.NewJSONHandler(&slogBuf, nil))).Info("no account", fields...)
+
+	var got map[string]interface{}
+	json.Unmarshal(slogBuf.Bytes(), &got)
+	delete(got, "time")
+	if got["level"] != "INFO" {
+		t.Errorf("Slog() level\n exp: INFO\n got: %v\n", got["level"])
+	}
+	delete(got, "level")
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("Slog() entry\n exp: %v\n got: %v\n", exp, got)
+	}
+
+	var logrusBuf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logrusBuf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	Logrus(logger).Error("no account", fields...)
+
+	got = nil
+	json.Unmarshal(logrusBuf.Bytes(), &got)
+	delete(got, "time")
+	if got["level"] != "error" {
+		t.Errorf("Logrus() level\n exp: error\n got: %v\n", got["level"])
+	}
+	delete(got, "level")
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("Logrus() entry\n exp: %v\n got: %v\n", exp, got)
+	}
+
+	core, logs := observer.New(zap.DebugLevel)
+	Zap(zap.New(core)).Debug("no account", fields...)
+
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].Level != zap.DebugLevel || entries[0].Message != "no account" {
+		t.Fatalf("Zap() entries\n exp: debug no account\n got: %v\n", entries)
+	}
+	zexp := map[string]interface{}{"status_code": int64(404), "error_id": "not_found", "account": "a1"}
+	if got := entries[0].ContextMap(); !reflect.DeepEqual(got, zexp) {
+		t.Errorf("Zap() fields\n exp: %v\n got: %v\n", zexp, got)
+	}
+}
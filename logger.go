@@ -0,0 +1,77 @@
+package errors
+
+// Field is a key value pair of a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger logs the errors written by the interceptors and http writers, see
+// SetLogger. Adapters for slog, zap and logrus are in the logadapters
+// package.
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+// SetLogger sets the Logger of the errors written by the grpc interceptors
+// and the http writers, nil to disable logging. Errors are logged at a level
+// derived from their Severity, see SetCodeDefaults: info at Debug, warning
+// at Info, error and critical at Error. Errors without a Severity are logged
+// from their code: internal_server class errors (5xx) at Error,
+// client_closed_request at Debug and the rest at Info.
+func SetLogger(l Logger) {
+	UpdateConfig(func(c *Config) { c.Logger = l })
+}
+
+// logError logs e with the Logger, if any, after applying the registered
+// scrubbers.
+func logError(e *Error) {
+	if e == nil {
+		return
+	}
+	l := e.cfg().Logger
+	if l == nil {
+		return
+	}
+	e = scrub(e)
+
+	msg := e.Message
+	if len(msg) == 0 {
+		msg = e.ErrorID()
+	}
+
+	severity, _ := e.Meta[SeverityKey].(string)
+	switch {
+	case severity == string(SeverityError) || severity == string(SeverityCritical):
+		l.Error(msg, e.LogFields()...)
+	case severity == string(SeverityWarning):
+		l.Info(msg, e.LogFields()...)
+	case severity == string(SeverityInfo):
+		l.Debug(msg, e.LogFields()...)
+	case e.StatusCode.httpStatus() >= 500:
+		l.Error(msg, e.LogFields()...)
+	case e.StatusCode == StatusClientClosedRequest:
+		l.Debug(msg, e.LogFields()...)
+	default:
+		l.Info(msg, e.LogFields()...)
+	}
+}
+
+// LogFields returns the fields describing the error in a log entry whose
+// message is the error Message, in the order of Error(): status_code,
+// error_id, desc and the Meta.
+func (e *Error) LogFields() []Field {
+	fields := []Field{
+		{"status_code", int(e.StatusCode)},
+		{"error_id", e.ErrorID()},
+	}
+	if e.InternalError != nil {
+		fields = append(fields, Field{"desc", e.desc()})
+	}
+	for _, key := range e.MetaKeys() {
+		fields = append(fields, Field{key, metaValue(e.Meta[key])})
+	}
+	return fields
+}
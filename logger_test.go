@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+type testLogger struct {
+	entries []string
+	fields  [][]Field
+}
+
+func (l *testLogger) log(level, msg string, fields []Field) {
+	l.entries = append(l.entries, fmt.Sprintf("%s %s", level, msg))
+	l.fields = append(l.fields, fields)
+}
+
+func (l *testLogger) Debug(msg string, fields ...Field) { l.log("debug", msg, fields) }
+func (l *testLogger) Info(msg string, fields ...Field)  { l.log("info", msg, fields) }
+func (l *testLogger) Error(msg string, fields ...Field) { l.log("error", msg, fields) }
+
+func TestLogger(t *testing.T) {
+	l := &testLogger{}
+	SetLogger(l)
+	defer SetLogger(nil)
+
+	WriteHTTP(httptest.NewRecorder(), NotFound("no account", SetMeta(Meta{"account": "a1"})))
+	WriteHTTP(httptest.NewRecorder(), InternalServer(""))
+	WriteHTTP(httptest.NewRecorder(), ClientClosedRequest("request canceled"))
+	WriteHTTP(httptest.NewRecorder(), NotFound("gone", SetMeta(Meta{SeverityKey: string(SeverityCritical)})))
+	WriteHTTP(httptest.NewRecorder(), InternalServer("flaky", SetMeta(Meta{SeverityKey: string(SeverityWarning)})))
+	WriteHTTP(httptest.NewRecorder(), BadRequest("noisy", SetMeta(Meta{SeverityKey: string(SeverityInfo)})))
+
+	interceptor := UnaryServerInterceptor()
+	interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("boom")
+	})
+
+	exp := []string{
+		"info no account",
+		"error internal_server",
+		"debug request canceled",
+		"error gone",
+		"info flaky",
+		"debug noisy",
+		"error unexpected error",
+	}
+	if !reflect.DeepEqual(l.entries, exp) {
+		t.Errorf("Logger entries\n exp: %q\n got: %q\n", exp, l.entries)
+	}
+
+	fields := []Field{{"status_code", 404}, {"error_id", "not_found"}, {"account", "a1"}}
+	if !reflect.DeepEqual(l.fields[0], fields) {
+		t.Errorf("Logger fields\n exp: %v\n got: %v\n", fields, l.fields[0])
+	}
+}
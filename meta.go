@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"encoding/json"
+)
+
+// MarshalJSON serialize meta to json. Values that are errors are serialized
+// as their description, unless they implement json.Marshaler like *Error,
+// which is serialized as a nested error object.
+func (m Meta) MarshalJSON() ([]byte, error) {
+	values := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		values[key] = metaValue(value)
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON deserialize meta from json, rehydrating nested error objects
+// as *Error.
+func (m *Meta) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*m = nil
+		return nil
+	}
+
+	meta := make(Meta, len(raw))
+	for key, buff := range raw {
+		var value interface{}
+		if err := json.Unmarshal(buff, &value); err != nil {
+			return err
+		}
+
+		if isErrorObject(value) {
+			var e Error
+			if err := json.Unmarshal(buff, &e); err == nil {
+				value = &e
+			}
+		}
+		meta[key] = value
+	}
+
+	*m = meta
+	return nil
+}
+
+// metaValue returns the value to serialize for a meta value.
+func metaValue(v interface{}) interface{} {
+	if _, ok := v.(json.Marshaler); ok {
+		return v
+	}
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return v
+}
+
+// isErrorObject reports whether v is a decoded json object of an *Error, in
+// any Naming.
+func isErrorObject(v interface{}) bool {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	_, hasID := obj["error_id"]
+	_, hasCode := obj["status_code"]
+	if hasID && hasCode {
+		return true
+	}
+
+	_, hasID = obj["errorId"]
+	_, hasCode = obj["statusCode"]
+	return hasID && hasCode
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMetaNestedErrors(t *testing.T) {
+	rollback := InternalServer("rollback failed", SetMeta(Meta{"tx": "t1"}))
+	err := BadRequest("transfer failed", SetMeta(Meta{
+		"rollback": rollback,
+		"cleanup":  errors.New("tmp file not removed"),
+	}))
+
+	buff, _ := json.Marshal(err)
+	exp := `{"meta":{"cleanup":"tmp file not removed","rollback":{"meta":{"tx":"t1"},"msg":"rollback failed","error_id":"internal_server","status_code":500}},"msg":"transfer failed","error_id":"bad_request","status_code":400}`
+	if string(buff) != exp {
+		t.Errorf("json.Marshal(%v)\n exp: %s\n got: %s\n", err, exp, buff)
+	}
+
+	decoded := BadRequest("transfer failed", SetMeta(Meta{
+		"rollback": rollback,
+		"cleanup":  "tmp file not removed",
+	}))
+
+	var got Error
+	if err := json.Unmarshal(buff, &got); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(&got, decoded) {
+		t.Errorf("json.Unmarshal(%s)\n exp: %#v\n got: %#v\n", buff, decoded, &got)
+	}
+
+	if got := FromGRPC(err.ToGRPC()); !reflect.DeepEqual(got, decoded) {
+		t.Errorf("FromGRPC(ToGRPC())\n exp: %#v\n got: %#v\n", decoded, got)
+	}
+
+	defer SetConfig(CurrentConfig())
+	SetNaming(CamelCase)
+
+	buff, _ = json.Marshal(err)
+	var camel Error
+	if err := json.Unmarshal(buff, &camel); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(&camel, decoded) {
+		t.Errorf("json.Unmarshal(%s) with CamelCase\n exp: %#v\n got: %#v\n", buff, decoded, &camel)
+	}
+}
+
+func TestSetMetaNoCopy(t *testing.T) {
+	m := Meta{"account": "a1"}
+	e := NotFound("no account", SetMetaNoCopy(m), SetMetaNoCopy(Meta{"bank": "b1"}))
+
+	if exp := (Meta{"account": "a1", "bank": "b1"}); !reflect.DeepEqual(e.Meta, exp) {
+		t.Errorf("SetMetaNoCopy()\n exp: %v\n got: %v\n", exp, e.Meta)
+	}
+	if reflect.ValueOf(e.Meta).Pointer() != reflect.ValueOf(m).Pointer() {
+		t.Errorf("SetMetaNoCopy() copied the given meta")
+	}
+}
+
+func BenchmarkSetMetaNoCopy(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NotFound("no account", SetMetaNoCopy(Meta{"account": "a1", "bank": "b1"}))
+	}
+}
+
+func TestSetMetaCopies(t *testing.T) {
+	template := Meta{"service": "transfers"}
+
+	first := NotFound("no account", SetMeta(template), SetMeta(Meta{"account": "a1"}))
+	second := NotFound("no bank", SetMeta(template), SetMeta(Meta{"bank": "b1"}))
+	template["service"] = "payments"
+
+	tests := []struct {
+		got Meta
+		exp Meta
+	}{
+		{template, Meta{"service": "payments"}},
+		{first.Meta, Meta{"service": "transfers", "account": "a1"}},
+		{second.Meta, Meta{"service": "transfers", "bank": "b1"}},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.exp) {
+			t.Errorf("SetMeta() with a shared map\n exp: %v\n got: %v\n", tt.exp, tt.got)
+		}
+	}
+
+	setter := SetMeta(Meta{"account": "a1"})
+	third, fourth := NotFound("", setter), NotFound("", setter)
+	third.Meta["bank"] = "b1"
+	if _, ok := fourth.Meta["bank"]; ok {
+		t.Errorf("SetMeta() setter shared its map between errors: %v", fourth.Meta)
+	}
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SetMetaStruct sets into the Meta of the error the fields of the struct v,
+// or pointer to struct, tagged with `meta:"name"`. Fields tagged with
+// `meta:"name,omitempty"` are skipped when they hold their zero value, and
+// untagged embedded structs are inspected too.
+//
+//	type transfer struct {
+//		ID     string `meta:"transfer_id"`
+//		Amount int64  `meta:"amount,omitempty"`
+//		Token  string // not copied
+//	}
+//
+//	errors.BadRequest("invalid transfer", errors.SetMetaStruct(t))
+func SetMetaStruct(v interface{}) errorParamsSetter {
+	meta := Meta{}
+	structMeta(reflect.ValueOf(v), meta)
+	return SetMeta(meta)
+}
+
+// structMeta sets into meta the tagged fields of the struct v.
+func structMeta(v reflect.Value, meta Meta) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("meta")
+
+		if !ok && field.Anonymous {
+			structMeta(v.Field(i), meta)
+			continue
+		}
+		if !ok || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		value := v.Field(i)
+		if opts == "omitempty" && value.IsZero() {
+			continue
+		}
+		meta[name] = value.Interface()
+	}
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetMetaStruct(t *testing.T) {
+	type base struct {
+		RequestID string `meta:"request_id"`
+	}
+	type transfer struct {
+		base
+		ID     string `meta:"transfer_id"`
+		Amount int64  `meta:"amount,omitempty"`
+		Bank   string `meta:",omitempty"`
+		Token  string
+		Secret string `meta:"-"`
+		hidden string `meta:"hidden"`
+	}
+
+	tests := []struct {
+		v   interface{}
+		exp Meta
+	}{
+		{
+			v:   transfer{base: base{"r1"}, ID: "t1", Amount: 100, Bank: "b1", Token: "x", Secret: "y", hidden: "z"},
+			exp: Meta{"request_id": "r1", "transfer_id": "t1", "amount": int64(100), "Bank": "b1"},
+		},
+		{
+			v:   &transfer{ID: "t1"},
+			exp: Meta{"request_id": "", "transfer_id": "t1"},
+		},
+		{
+			v:   (*transfer)(nil),
+			exp: Meta{},
+		},
+		{
+			v:   "not a struct",
+			exp: Meta{},
+		},
+	}
+
+	for _, tt := range tests {
+		got := BadRequest("", SetMetaStruct(tt.v)).Meta
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("SetMetaStruct(%#v)\n exp: %v\n got: %v\n", tt.v, tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"os"
+	"strings"
+)
+
+// Mode controls how much information errors expose when serialized.
+type Mode int32
+
+// Serialization modes
+const (
+	// Production omits internal errors and internal meta from JSON and gRPC
+	// output.
+	Production Mode = iota
+	// Debug includes internal errors, internal meta and the stack captured
+	// on construction, see CaptureStacks, in JSON and gRPC output.
+	Debug
+	// Sanitized is like Production, but the http writers also replace
+	// internal_server class errors with their Sanitize version. The full
+	// error is reported to the sinks.
+	Sanitized
+)
+
+// ModeEnv is the environment variable read at startup to set the mode, e.g.
+// ERRORS_MODE=debug or ERRORS_MODE=sanitized.
+const ModeEnv = "ERRORS_MODE"
+
+// modeFromEnv returns the mode set by ModeEnv, Production by default.
+func modeFromEnv() Mode {
+	switch strings.ToLower(os.Getenv(ModeEnv)) {
+	case "debug":
+		return Debug
+	case "sanitized":
+		return Sanitized
+	}
+	return Production
+}
+
+// SetMode sets the serialization mode, Production by default.
+func SetMode(m Mode) {
+	UpdateConfig(func(c *Config) { c.Mode = m })
+}
+
+// CurrentMode returns the serialization mode.
+func CurrentMode() Mode {
+	return loadConfig().Mode
+}
+
+// internal returns the internal error description and meta to serialize
+// according to the current mode.
+func (e *Error) internal() (string, Meta) {
+	if e.cfg().Mode != Debug {
+		return "", nil
+	}
+
+	var desc string
+	if e.InternalError != nil {
+		desc = e.desc()
+	}
+	return desc, e.InternalMeta
+}
+
+// debugStack returns the stack captured on construction to serialize
+// according to the current mode.
+func (e *Error) debugStack() []string {
+	if e.cfg().Mode != Debug || len(e.stack) == 0 {
+		return nil
+	}
+	return e.stackEntries()
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMode(t *testing.T) {
+	defer SetMode(Production)
+
+	err := InternalServerFromError(errors.New("db down"), "", SetInternalMeta(Meta{"query": "select"}))
+
+	tests := []struct {
+		mode Mode
+		json string
+		grpc *Error
+	}{
+		{
+			mode: Production,
+			json: `{"error_id":"internal_server","status_code":500}`,
+			grpc: InternalServer(""),
+		},
+		{
+			mode: Debug,
+			json: `{"error_id":"internal_server","status_code":500,"internal_error":"db down","internal_meta":{"query":"select"}}`,
+			grpc: err,
+		},
+	}
+
+	for _, tt := range tests {
+		SetMode(tt.mode)
+
+		got, _ := json.Marshal(err)
+		if string(got) != tt.json {
+			t.Errorf("json.Marshal(%v) mode %d\n exp: %s\n got: %s\n", err, tt.mode, tt.json, got)
+		}
+
+		e := FromGRPC(err.ToGRPC())
+		if !reflect.DeepEqual(e, tt.grpc) {
+			t.Errorf("FromGRPC(ToGRPC(%v)) mode %d\n exp: %#v\n got: %#v\n", err, tt.mode, tt.grpc, e)
+		}
+	}
+}
+
+func TestModeStack(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	CaptureStacks(true)
+	err := NotFound("no account")
+
+	for mode, exp := range map[Mode]bool{Production: false, Debug: true} {
+		SetMode(mode)
+
+		var obj struct{ Stack []string }
+		b, _ := json.Marshal(err)
+		json.Unmarshal(b, &obj)
+		if got := len(obj.Stack) > 0 && strings.Contains(obj.Stack[0], "TestModeStack"); got != exp {
+			t.Errorf("json.Marshal(%v) mode %d stack\n exp: %t\n got: %s\n", err, mode, exp, b)
+		}
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	tests := []struct {
+		env string
+		exp Mode
+	}{
+		{"", Production},
+		{"DEBUG", Debug},
+		{"sanitized", Sanitized},
+		{"verbose", Production},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(ModeEnv, tt.env)
+		if got := modeFromEnv(); got != tt.exp {
+			t.Errorf("modeFromEnv() with %s=%q\n exp: %d\n got: %d\n", ModeEnv, tt.env, tt.exp, got)
+		}
+	}
+}
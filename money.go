@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"fmt"
+	"math"
+	"text/template"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// templateFuncs returns the functions available to translations of the given
+// language:
+//
+//	money AMOUNT CURRENCY
+//		formats an amount in minor units of the ISO 4217 currency, e.g.
+//		{{money .missing .currency}} renders "CLP 12.500" in es-CL.
+func templateFuncs(tag language.Tag) template.FuncMap {
+	printer := message.NewPrinter(tag)
+
+	return template.FuncMap{
+		"money": func(amount interface{}, code string) (string, error) {
+			unit, err := currency.ParseISO(code)
+			if err != nil {
+				return "", err
+			}
+
+			minor, ok := toInt64(amount)
+			if !ok {
+				return "", fmt.Errorf("errors: invalid money amount %v", amount)
+			}
+
+			scale, _ := currency.Standard.Rounding(unit)
+			major := float64(minor) / math.Pow10(scale)
+
+			return printer.Sprintf("%v %v", unit, number.Decimal(major, number.Scale(scale))), nil
+		},
+	}
+}
+
+// toInt64 converts the numeric values found in Meta to int64.
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MarshalMsgpack implements msgpack.Marshaler. The error is encoded as the
+// msgpack form of the object written by MarshalJSON, so both encodings carry
+// the same fields.
+func (e *Error) MarshalMsgpack() ([]byte, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(obj)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler. Meta values are decoded
+// as UnmarshalJSON decodes them, e.g. numbers as float64.
+func (e *Error) UnmarshalMsgpack(b []byte) error {
+	var obj map[string]interface{}
+	if err := msgpack.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+
+	buff, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalJSON(buff)
+}
@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack(t *testing.T) {
+	tests := []struct {
+		err *Error
+		exp *Error
+	}{
+		{BadRequest("let's go", SetMeta(Meta{"hi": "ho"})), BadRequest("let's go", SetMeta(Meta{"hi": "ho"}))},
+		{RateLimit("slow down", SetMeta(Meta{"limit": 10})), RateLimit("slow down", SetMeta(Meta{"limit": float64(10)}))},
+		{New(418, "teapot", func(e *Error) { e.FallbackAllowed = true }), New(418, "teapot", func(e *Error) { e.FallbackAllowed = true })},
+	}
+
+	for _, tt := range tests {
+		b, err := msgpack.Marshal(tt.err)
+		if err != nil {
+			t.Fatalf("msgpack.Marshal(%v) unexpected error: %v", tt.err, err)
+		}
+
+		var got *Error
+		if err := msgpack.Unmarshal(b, &got); err != nil {
+			t.Fatalf("msgpack.Unmarshal(%x) unexpected error: %v", b, err)
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("msgpack round trip of %v\n exp: %#v\n got: %#v\n", tt.err, tt.exp, got)
+		}
+	}
+
+	var e Error
+	if err := msgpack.Unmarshal([]byte{0xc1}, &e); err == nil {
+		t.Errorf("msgpack.Unmarshal() expected error for invalid msgpack")
+	}
+}
@@ -0,0 +1,164 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// Naming is the style of the field and meta keys in JSON output.
+type Naming int32
+
+// Naming styles
+const (
+	// DefaultNaming renders fields in snake_case and meta keys as given.
+	DefaultNaming Naming = iota
+	// SnakeCase renders fields and meta keys, including those of nested
+	// maps, in snake_case, e.g. request_id.
+	SnakeCase
+	// CamelCase renders fields and meta keys, including those of nested
+	// maps, in camelCase, e.g. requestId.
+	CamelCase
+)
+
+// SetNaming sets the style of the keys in JSON output, DefaultNaming by
+// default. UnmarshalJSON accepts fields in any style.
+func SetNaming(n Naming) {
+	UpdateConfig(func(c *Config) { c.Naming = n })
+}
+
+// currentNaming returns the style of the keys in JSON output.
+func currentNaming() Naming {
+	return loadConfig().Naming
+}
+
+// jsonError is the json object of an Error.
+type jsonError struct {
+	Meta          json.RawMessage  `json:"meta,omitempty"`
+	Message       string           `json:"msg,omitempty"`
+	UserMessage   string           `json:"user_msg,omitempty"`
+	ErrorID       string           `json:"error_id"`
+	StatusCode    Code             `json:"status_code"`
+	Fallback      bool             `json:"fallback_allowed,omitempty"`
+	InternalError string           `json:"internal_error,omitempty"`
+	InternalMeta  Meta             `json:"internal_meta,omitempty"`
+	Stack         []string         `json:"stack,omitempty"`
+	Errors        []json.Marshaler `json:"errors,omitempty"`
+	Suppressed    []json.Marshaler `json:"suppressed,omitempty"`
+}
+
+// camelJSONError is jsonError with fields in camelCase.
+type camelJSONError struct {
+	Meta          json.RawMessage  `json:"meta,omitempty"`
+	Message       string           `json:"msg,omitempty"`
+	UserMessage   string           `json:"userMsg,omitempty"`
+	ErrorID       string           `json:"errorId"`
+	StatusCode    Code             `json:"statusCode"`
+	Fallback      bool             `json:"fallbackAllowed,omitempty"`
+	InternalError string           `json:"internalError,omitempty"`
+	InternalMeta  Meta             `json:"internalMeta,omitempty"`
+	Stack         []string         `json:"stack,omitempty"`
+	Errors        []json.Marshaler `json:"errors,omitempty"`
+	Suppressed    []json.Marshaler `json:"suppressed,omitempty"`
+}
+
+// named returns a copy of e with the meta keys in the given style, or e
+// itself for DefaultNaming.
+func (e *Error) named(n Naming) *Error {
+	if n == DefaultNaming {
+		return e
+	}
+
+	c := *e
+	c.Meta = renameMeta(e.Meta, n)
+	c.InternalMeta = renameMeta(e.InternalMeta, n)
+	c.metaOrder = make([]string, len(e.metaOrder))
+	for i, key := range e.metaOrder {
+		c.metaOrder[i] = rename(key, n)
+	}
+	return &c
+}
+
+// renameMeta returns a copy of m with the keys, including those of nested
+// maps, in the given style.
+func renameMeta(m Meta, n Naming) Meta {
+	if m == nil {
+		return nil
+	}
+
+	renamed := make(Meta, len(m))
+	for key, value := range m {
+		renamed[rename(key, n)] = renameValue(value, n)
+	}
+	return renamed
+}
+
+// renameValue returns v with the keys of nested maps in the given style.
+func renameValue(v interface{}, n Naming) interface{} {
+	switch v := v.(type) {
+	case Meta:
+		return renameMeta(v, n)
+	case map[string]interface{}:
+		return map[string]interface{}(renameMeta(v, n))
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			values[i] = renameValue(value, n)
+		}
+		return values
+	}
+	return v
+}
+
+// rename returns key in the given style.
+func rename(key string, n Naming) string {
+	switch n {
+	case SnakeCase:
+		return toSnake(key)
+	case CamelCase:
+		return toCamel(key)
+	}
+	return key
+}
+
+// toSnake returns s in snake_case, e.g. requestID and request-id are
+// returned as request_id.
+func toSnake(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ':
+			b.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// toCamel returns s in camelCase, e.g. request_id and request-id are
+// returned as requestId.
+func toCamel(s string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range s {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			upper = b.Len() > 0
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
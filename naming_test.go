@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNaming(t *testing.T) {
+	defer SetNaming(DefaultNaming)
+
+	e := NotFound("no account", SetMeta(Meta{
+		"accountID": "a1",
+		"bank_name": Meta{"swiftCode": "X"},
+		"items":     []interface{}{map[string]interface{}{"item-id": 1}},
+	}))
+	e.UserMessage = "not found"
+
+	tests := []struct {
+		naming Naming
+		exp    string
+	}{
+		{DefaultNaming, `{"meta":{"accountID":"a1","bank_name":{"swiftCode":"X"},"items":[{"item-id":1}]},"msg":"no account","user_msg":"not found","error_id":"not_found","status_code":404}`},
+		{SnakeCase, `{"meta":{"account_id":"a1","bank_name":{"swift_code":"X"},"items":[{"item_id":1}]},"msg":"no account","user_msg":"not found","error_id":"not_found","status_code":404}`},
+		{CamelCase, `{"meta":{"accountID":"a1","bankName":{"swiftCode":"X"},"items":[{"itemId":1}]},"msg":"no account","userMsg":"not found","errorId":"not_found","statusCode":404}`},
+	}
+
+	for _, tt := range tests {
+		SetNaming(tt.naming)
+		got, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("Marshal() unexpected error: %v", err)
+		}
+		if string(got) != tt.exp {
+			t.Errorf("Marshal() with naming %d\n exp: %s\n got: %s\n", tt.naming, tt.exp, got)
+		}
+
+		var decoded Error
+		if err := json.Unmarshal(got, &decoded); err != nil || decoded.StatusCode != e.StatusCode || decoded.UserMessage != e.UserMessage {
+			t.Errorf("Unmarshal() with naming %d\n exp: %v\n got: %v (%v)\n", tt.naming, e, &decoded, err)
+		}
+	}
+}
+
+func TestToSnakeToCamel(t *testing.T) {
+	tests := []struct {
+		key, snake, camel string
+	}{
+		{"request_id", "request_id", "requestId"},
+		{"requestId", "request_id", "requestId"},
+		{"requestID", "request_id", "requestID"},
+		{"HTTPStatus", "http_status", "HTTPStatus"},
+		{"item-id", "item_id", "itemId"},
+		{"_private", "_private", "private"},
+	}
+
+	for _, tt := range tests {
+		if got := toSnake(tt.key); got != tt.snake {
+			t.Errorf("toSnake(%q)\n exp: %q\n got: %q\n", tt.key, tt.snake, got)
+		}
+		if got := toCamel(tt.key); got != tt.camel {
+			t.Errorf("toCamel(%q)\n exp: %q\n got: %q\n", tt.key, tt.camel, got)
+		}
+	}
+}
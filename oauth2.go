@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// OAuth2ErrorKey is the Meta key holding the OAuth2 error code of the error,
+// e.g. "invalid_grant", overriding the one derived from its code.
+const OAuth2ErrorKey = "oauth2_error"
+
+// OAuth2Endpoint is the kind of endpoint an OAuth2 error is written by,
+// which determines the OAuth2 error codes derived from the error codes.
+type OAuth2Endpoint int
+
+// OAuth2 endpoints
+const (
+	// TokenEndpoint is the token endpoint of an authorization server, whose
+	// errors are defined by RFC 6749.
+	TokenEndpoint OAuth2Endpoint = iota
+	// ResourceEndpoint is an endpoint protected by bearer tokens, whose
+	// errors are defined by RFC 6750.
+	ResourceEndpoint
+)
+
+// tokenRealm is the realm of the WWW-Authenticate header of invalid_client
+// errors of token endpoints.
+const tokenRealm = "oauth2"
+
+// OAuth2Response is an OAuth2 error response.
+type OAuth2Response struct {
+	Error       string `json:"error"` // e.g. "invalid_token"
+	Description string `json:"error_description,omitempty"`
+	URI         string `json:"error_uri,omitempty"`
+}
+
+// OAuth2 returns the OAuth2 error response of the error written by the
+// given kind of endpoint. The error code is taken from the Meta under
+// OAuth2ErrorKey or derived from the error code, e.g. invalid_client for
+// unauthorized errors of the token endpoint and invalid_token for the ones of
+// resource endpoints. The error_uri is the Meta value of HelpURLKey.
+func (e *Error) OAuth2(endpoint OAuth2Endpoint) OAuth2Response {
+	r := OAuth2Response{Error: e.oauth2Code(endpoint), Description: e.UserMessage}
+	if len(r.Description) == 0 {
+		r.Description = e.Message
+	}
+	if uri, ok := e.Meta[HelpURLKey].(string); ok {
+		r.URI = uri
+	}
+	return r
+}
+
+// oauth2Code returns the OAuth2 error code of the error.
+func (e *Error) oauth2Code(endpoint OAuth2Endpoint) string {
+	if code, ok := e.Meta[OAuth2ErrorKey].(string); ok && len(code) > 0 {
+		return code
+	}
+
+	switch status := e.StatusCode.httpStatus(); {
+	case status >= 500:
+		return "server_error"
+	case status == http.StatusUnauthorized && endpoint == TokenEndpoint:
+		return "invalid_client"
+	case status == http.StatusUnauthorized:
+		return "invalid_token"
+	case status == http.StatusForbidden && endpoint == TokenEndpoint:
+		return "unauthorized_client"
+	case status == http.StatusForbidden:
+		return "insufficient_scope"
+	}
+	return "invalid_request"
+}
+
+// oauth2Codes maps the OAuth2 error codes into the errors taxonomy.
+var oauth2Codes = map[string]Code{
+	"invalid_request":           StatusBadRequest,
+	"invalid_grant":             StatusBadRequest,
+	"invalid_scope":             StatusBadRequest,
+	"unsupported_grant_type":    StatusBadRequest,
+	"unsupported_response_type": StatusBadRequest,
+	"invalid_client":            StatusUnauthorized,
+	"invalid_token":             StatusUnauthorized,
+	"unauthorized_client":       StatusForbidden,
+	"access_denied":             StatusForbidden,
+	"insufficient_scope":        StatusForbidden,
+	"server_error":              StatusInternalServerError,
+	"temporarily_unavailable":   StatusInternalServerError,
+}
+
+// FromOAuth2 returns a new Error from an OAuth2 error response, keeping its
+// error code in Meta under OAuth2ErrorKey and its error_uri under
+// HelpURLKey. Unknown error codes are bad_request errors.
+func FromOAuth2(r OAuth2Response) *Error {
+	code, ok := oauth2Codes[r.Error]
+	if !ok {
+		code = StatusBadRequest
+	}
+
+	meta := Meta{OAuth2ErrorKey: r.Error}
+	if len(r.URI) > 0 {
+		meta[HelpURLKey] = r.URI
+	}
+	return New(code, r.Description, SetMetaNoCopy(meta))
+}
+
+// FromOAuth2Response is like FromHTTPResponse but decodes the OAuth2 error
+// response of an authorization server or resource endpoint. The body is
+// consumed and closed.
+func FromOAuth2Response(resp *http.Response) *Error {
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return InternalServerFromError(err, UnexpectedMsg)
+	}
+
+	var r OAuth2Response
+	if err := json.Unmarshal(body, &r); err != nil || len(r.Error) == 0 {
+		return New(Code(resp.StatusCode), http.StatusText(resp.StatusCode))
+	}
+	return FromOAuth2(r)
+}
+
+// WriteOAuth2 is like WriteHTTP but writes the OAuth2 error response of the
+// given kind of endpoint. Token endpoint responses are not cached and, as
+// required by RFC 6749 section 5.2, written with status 400 except for
+// invalid_client, written with status 401 and the WWW-Authenticate header of
+// basic client authentication, server_error, written with the 5xx status of
+// the error, and temporarily_unavailable, written with status 503. Resource
+// endpoints keep the status of the error and set the WWW-Authenticate header
+// of bearer tokens to 401 responses and 403 insufficient_scope ones.
+func WriteOAuth2(w http.ResponseWriter, err error, endpoint OAuth2Endpoint) {
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+	logError(e)
+	e = scrub(e.sanitized())
+
+	r := e.OAuth2(endpoint)
+	status := e.StatusCode.httpStatus()
+	if endpoint == TokenEndpoint {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Pragma", "no-cache")
+
+		switch r.Error {
+		case "invalid_client":
+			status = http.StatusUnauthorized
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, tokenRealm))
+		case "server_error":
+			if status < 500 {
+				status = http.StatusInternalServerError
+			}
+		case "temporarily_unavailable":
+			status = http.StatusServiceUnavailable
+		default:
+			status = http.StatusBadRequest
+		}
+	} else if status == http.StatusUnauthorized || (status == http.StatusForbidden && r.Error == "insufficient_scope") {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="%s", error_description="%s"`, r.Error, authParam(r.Description)))
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(r) // there is no much more to do in case of failure
+}
+
+// authParam returns s without the characters not allowed in the
+// WWW-Authenticate error_description, see RFC 6750 section 3.
+func authParam(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, s)
+}
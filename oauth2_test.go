@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOAuth2(t *testing.T) {
+	tests := []struct {
+		err      *Error
+		endpoint OAuth2Endpoint
+		exp      OAuth2Response
+	}{
+		{Unauthorized("bad credentials"), TokenEndpoint, OAuth2Response{Error: "invalid_client", Description: "bad credentials"}},
+		{Unauthorized("token expired"), ResourceEndpoint, OAuth2Response{Error: "invalid_token", Description: "token expired"}},
+		{Forbidden("missing accounts:read"), ResourceEndpoint, OAuth2Response{Error: "insufficient_scope", Description: "missing accounts:read"}},
+		{Forbidden("client not allowed"), TokenEndpoint, OAuth2Response{Error: "unauthorized_client", Description: "client not allowed"}},
+		{BadRequest("code reused", SetMeta(Meta{OAuth2ErrorKey: "invalid_grant", HelpURLKey: "https://docs.finciero.com/oauth"})), TokenEndpoint,
+			OAuth2Response{Error: "invalid_grant", Description: "code reused", URI: "https://docs.finciero.com/oauth"}},
+		{InternalServer("db down"), ResourceEndpoint, OAuth2Response{Error: "server_error", Description: "db down"}},
+	}
+
+	for _, tt := range tests {
+		got := tt.err.OAuth2(tt.endpoint)
+		if got != tt.exp {
+			t.Errorf("(%v).OAuth2(%d)\n exp: %+v\n got: %+v\n", tt.err, tt.endpoint, tt.exp, got)
+		}
+		if back := FromOAuth2(got); back.StatusCode != tt.err.StatusCode {
+			t.Errorf("FromOAuth2(%+v)\n exp: %d\n got: %d\n", got, tt.err.StatusCode, back.StatusCode)
+		}
+	}
+}
+
+func TestFromOAuth2Response(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		exp    *Error
+	}{
+		{http.StatusBadRequest, `{"error":"invalid_grant","error_description":"code expired"}`, BadRequest("code expired", SetMeta(Meta{OAuth2ErrorKey: "invalid_grant"}))},
+		{http.StatusUnauthorized, `{"error":"invalid_token","error_uri":"https://docs.finciero.com/oauth"}`,
+			Unauthorized("", SetMeta(Meta{OAuth2ErrorKey: "invalid_token", HelpURLKey: "https://docs.finciero.com/oauth"}))},
+		{http.StatusBadGateway, `<html></html>`, New(Code(http.StatusBadGateway), "Bad Gateway")},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Body: ioutil.NopCloser(strings.NewReader(tt.body))}
+		if got := FromOAuth2Response(resp); !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("FromOAuth2Response(%s)\n exp: %v\n got: %v\n", tt.body, tt.exp, got)
+		}
+	}
+}
+
+func TestWriteOAuth2(t *testing.T) {
+	tests := []struct {
+		err      *Error
+		endpoint OAuth2Endpoint
+		status   int
+		header   string
+		exp      string
+	}{
+		{Unauthorized("bad secret"), TokenEndpoint, http.StatusUnauthorized, "WWW-Authenticate", `Basic realm="oauth2"`},
+		{Unauthorized("bad secret"), TokenEndpoint, http.StatusUnauthorized, "Cache-Control", "no-store"},
+		{Forbidden("client not allowed"), TokenEndpoint, http.StatusBadRequest, "WWW-Authenticate", ""},
+		{InternalServer("db down"), TokenEndpoint, http.StatusInternalServerError, "Cache-Control", "no-store"},
+		{InternalServer("maintenance", SetMeta(Meta{OAuth2ErrorKey: "temporarily_unavailable"})), TokenEndpoint, http.StatusServiceUnavailable, "Cache-Control", "no-store"},
+		{BadRequest("code reused", SetMeta(Meta{OAuth2ErrorKey: "invalid_grant"})), TokenEndpoint, http.StatusBadRequest, "WWW-Authenticate", ""},
+		{Unauthorized(`"token" expired`), ResourceEndpoint, http.StatusUnauthorized, "WWW-Authenticate", `Bearer error="invalid_token", error_description="token expired"`},
+		{Forbidden("missing accounts:read"), ResourceEndpoint, http.StatusForbidden, "WWW-Authenticate", `Bearer error="insufficient_scope", error_description="missing accounts:read"`},
+		{Forbidden("blocked", SetMeta(Meta{OAuth2ErrorKey: "access_denied"})), ResourceEndpoint, http.StatusForbidden, "WWW-Authenticate", ""},
+		{InternalServer("db down"), ResourceEndpoint, http.StatusInternalServerError, "WWW-Authenticate", ""},
+		{BadRequest("missing token"), ResourceEndpoint, http.StatusBadRequest, "WWW-Authenticate", ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		WriteOAuth2(rec, tt.err, tt.endpoint)
+
+		if got := rec.Header().Get(tt.header); got != tt.exp {
+			t.Errorf("WriteOAuth2(%v, %d) %s\n exp: %s\n got: %s\n", tt.err, tt.endpoint, tt.header, tt.exp, got)
+		}
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), `"error":`) {
+			t.Errorf("WriteOAuth2(%v, %d)\n exp: %d oauth2 error response\n got: %d %s\n", tt.err, tt.endpoint, tt.status, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxFingerprints bounds the number of fingerprints counted, since messages
+// may be unbounded.
+const maxFingerprints = 4096
+
+// occurrenceBuckets is the number of one minute buckets kept per
+// fingerprint, the longest window OccurrencesWithin can count.
+const occurrenceBuckets = 60
+
+// now returns the current time, overridable by tests.
+var now = time.Now
+
+// occurrence counts the reports of a fingerprint.
+type occurrence struct {
+	total    int
+	exported int
+	buckets  [occurrenceBuckets]int
+	minutes  [occurrenceBuckets]int64
+	last     int64 // minute of the last report
+}
+
+var occurrences = struct {
+	sync.Mutex
+	counts  map[string]*occurrence
+	evicted int64 // minute of the last eviction
+}{
+	counts: map[string]*occurrence{},
+}
+
+// OccurrenceSink is implemented by sinks that also want the occurrence
+// counts exported by ExportOccurrences.
+type OccurrenceSink interface {
+	// ReportOccurrences receives the reports per fingerprint since the
+	// previous export.
+	ReportOccurrences(counts map[string]int)
+}
+
+// count records an occurrence of the error, keyed by its fingerprint.
+func count(e *Error) {
+	fingerprint := e.Fingerprint()
+	minute := now().Unix() / 60
+
+	occurrences.Lock()
+	defer occurrences.Unlock()
+
+	o, ok := occurrences.counts[fingerprint]
+	if !ok {
+		if len(occurrences.counts) >= maxFingerprints && !evictOccurrences(minute) {
+			return
+		}
+		o = &occurrence{}
+		occurrences.counts[fingerprint] = o
+	}
+
+	i := minute % occurrenceBuckets
+	if o.minutes[i] != minute {
+		o.minutes[i], o.buckets[i] = minute, 0
+	}
+	o.buckets[i]++
+	o.total++
+	o.last = minute
+}
+
+// evictOccurrences removes the fingerprints not reported within the last
+// hour, the longest window OccurrencesWithin can count, reporting whether
+// there is room for a new one. It scans the counts at most once a minute. It
+// must be called with occurrences locked.
+func evictOccurrences(minute int64) bool {
+	if occurrences.evicted == minute {
+		return false
+	}
+	occurrences.evicted = minute
+
+	for fingerprint, o := range occurrences.counts {
+		if o.last <= minute-occurrenceBuckets {
+			delete(occurrences.counts, fingerprint)
+		}
+	}
+	return len(occurrences.counts) < maxFingerprints
+}
+
+// Occurrences returns the number of reported errors with the given
+// fingerprint since the process started.
+func Occurrences(fingerprint string) int {
+	occurrences.Lock()
+	defer occurrences.Unlock()
+
+	if o, ok := occurrences.counts[fingerprint]; ok {
+		return o.total
+	}
+	return 0
+}
+
+// OccurrencesWithin returns the number of reported errors with the given
+// fingerprint in the last d, rounded up to whole minutes and at most an
+// hour.
+//
+//	if errors.OccurrencesWithin(e.Fingerprint(), 5*time.Minute) > 10 {
+//		alert(e)
+//	}
+func OccurrencesWithin(fingerprint string, d time.Duration) int {
+	minutes := int64((d + time.Minute - 1) / time.Minute)
+	if minutes > occurrenceBuckets {
+		minutes = occurrenceBuckets
+	}
+	current := now().Unix() / 60
+
+	occurrences.Lock()
+	defer occurrences.Unlock()
+
+	o, ok := occurrences.counts[fingerprint]
+	if !ok {
+		return 0
+	}
+
+	var n int
+	for i, minute := range o.minutes {
+		if minute > current-minutes && minute <= current {
+			n += o.buckets[i]
+		}
+	}
+	return n
+}
+
+// ExportOccurrences sends the occurrence counts to the registered sinks
+// implementing OccurrenceSink every interval, until ctx is done.
+func ExportOccurrences(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			exportOccurrences()
+		}
+	}
+}
+
+// exportOccurrences sends the counts since the previous export to the
+// registered sinks implementing OccurrenceSink.
+func exportOccurrences() {
+	occurrences.Lock()
+	counts := map[string]int{}
+	for fingerprint, o := range occurrences.counts {
+		if n := o.total - o.exported; n > 0 {
+			counts[fingerprint] = n
+			o.exported = o.total
+		}
+	}
+	occurrences.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+
+	for _, s := range loadConfig().Sinks {
+		if sink, ok := s.(OccurrenceSink); ok {
+			sink.ReportOccurrences(counts)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testOccurrenceSink struct {
+	testSink
+	counts []map[string]int
+}
+
+func (s *testOccurrenceSink) ReportOccurrences(counts map[string]int) {
+	s.counts = append(s.counts, counts)
+}
+
+func TestOccurrences(t *testing.T) {
+	current := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+
+	occurrences.counts = map[string]*occurrence{}
+	sink := &testOccurrenceSink{}
+	AddSink(sink)
+	defer func() {
+		now = time.Now
+		UpdateConfig(func(c *Config) { c.Sinks = nil })
+		occurrences.counts = map[string]*occurrence{}
+	}()
+
+	e := InternalServer("db down")
+	fingerprint := e.Fingerprint()
+
+	Report(e)
+	current = current.Add(3 * time.Minute)
+	Report(e)
+	Report(InternalServer("db down"))
+
+	tests := []struct {
+		within time.Duration
+		exp    int
+	}{
+		{time.Minute, 2},
+		{3 * time.Minute, 2},
+		{4 * time.Minute, 3},
+		{2 * time.Hour, 3},
+	}
+
+	for _, tt := range tests {
+		if got := OccurrencesWithin(fingerprint, tt.within); got != tt.exp {
+			t.Errorf("OccurrencesWithin(%v)\n exp: %d\n got: %d\n", tt.within, tt.exp, got)
+		}
+	}
+
+	if got := Occurrences(fingerprint); got != 3 {
+		t.Errorf("Occurrences()\n exp: 3\n got: %d\n", got)
+	}
+	if got := Occurrences("unknown"); got != 0 {
+		t.Errorf("Occurrences(unknown)\n exp: 0\n got: %d\n", got)
+	}
+
+	exportOccurrences()
+	Report(e)
+	exportOccurrences()
+	exportOccurrences()
+
+	exp := []map[string]int{{fingerprint: 3}, {fingerprint: 1}}
+	if !reflect.DeepEqual(sink.counts, exp) {
+		t.Errorf("ReportOccurrences()\n exp: %v\n got: %v\n", exp, sink.counts)
+	}
+}
+
+func TestOccurrencesEviction(t *testing.T) {
+	current := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+
+	occurrences.counts = map[string]*occurrence{}
+	defer func() {
+		now = time.Now
+		occurrences.counts = map[string]*occurrence{}
+		occurrences.evicted = 0
+	}()
+
+	stale := InternalServer("stale")
+	count(stale)
+	current = current.Add(30 * time.Minute)
+	for i := 1; i < maxFingerprints; i++ {
+		count(InternalServer(fmt.Sprintf("error %d", i)))
+	}
+
+	fresh := InternalServer("fresh")
+	count(fresh)
+	if got := Occurrences(fresh.Fingerprint()); got != 0 {
+		t.Errorf("Occurrences() past maxFingerprints with fresh fingerprints\n exp: 0\n got: %d\n", got)
+	}
+
+	current = current.Add(31 * time.Minute)
+	count(fresh)
+	if got := Occurrences(fresh.Fingerprint()); got != 1 {
+		t.Errorf("Occurrences() after evicting stale fingerprints\n exp: 1\n got: %d\n", got)
+	}
+	if got := Occurrences(stale.Fingerprint()); got != 0 {
+		t.Errorf("Occurrences() of an evicted fingerprint\n exp: 0\n got: %d\n", got)
+	}
+}
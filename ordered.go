@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SetOrderedMeta sets the given alternating keys and values into the Meta
+// of the error, remembering their insertion order. Ordered keys come first,
+// in that order, in Error(), JSON and gRPC serialization. Non string keys
+// are formatted with fmt.Sprint and a trailing key without value is ignored.
+//
+//	errors.NotFound("no account", errors.SetOrderedMeta("account", id, "bank", bank))
+func SetOrderedMeta(keyvals ...interface{}) errorParamsSetter {
+	return func(e *Error) {
+		if e.Meta == nil {
+			e.Meta = Meta{}
+		}
+
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			key, ok := keyvals[i].(string)
+			if !ok {
+				key = fmt.Sprint(keyvals[i])
+			}
+
+			if _, exists := e.Meta[key]; !exists || !e.isOrdered(key) {
+				e.metaOrder = append(e.metaOrder, key)
+			}
+			e.Meta[key] = keyvals[i+1]
+		}
+	}
+}
+
+// isOrdered reports whether key has a recorded insertion order.
+func (e *Error) isOrdered(key string) bool {
+	for _, k := range e.metaOrder {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// MetaKeys returns the Meta keys in serialization order: keys set with
+// SetOrderedMeta in insertion order, followed by the rest sorted.
+func (e *Error) MetaKeys() []string {
+	keys := make([]string, 0, len(e.Meta))
+	for _, key := range e.metaOrder {
+		if _, ok := e.Meta[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+
+	n := len(keys)
+	for key := range e.Meta {
+		if !e.isOrdered(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys[n:])
+
+	return keys
+}
+
+// marshalMeta returns the json object of the error Meta, with keys in
+// MetaKeys order, or nil if it is empty.
+func (e *Error) marshalMeta() (json.RawMessage, error) {
+	if len(e.Meta) == 0 {
+		return nil, nil
+	}
+	if len(e.metaOrder) == 0 {
+		return json.Marshal(e.Meta)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range e.MetaKeys() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(metaValue(e.Meta[key]))
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
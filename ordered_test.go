@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSetOrderedMeta(t *testing.T) {
+	err := NotFound("no account",
+		SetMeta(Meta{"b": "1", "a": "2"}),
+		SetOrderedMeta("zeta", "z", "alpha", "a", "zeta", "z2"),
+	)
+
+	if exp, got := []string{"zeta", "alpha", "a", "b"}, err.MetaKeys(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("MetaKeys()\n exp: %q\n got: %q\n", exp, got)
+	}
+
+	if exp, got := `status_code=404 error_id="not_found" msg="no account" zeta="z2" alpha="a" a="2" b="1"`, err.Error(); got != exp {
+		t.Errorf("Error()\n exp: %s\n got: %s\n", exp, got)
+	}
+
+	buff, _ := json.Marshal(err)
+	if exp := `{"meta":{"zeta":"z2","alpha":"a","a":"2","b":"1"},"msg":"no account","error_id":"not_found","status_code":404}`; string(buff) != exp {
+		t.Errorf("json.Marshal()\n exp: %s\n got: %s\n", exp, buff)
+	}
+
+	got := FromGRPC(err.ToGRPC())
+	if !reflect.DeepEqual(got.MetaKeys(), err.MetaKeys()) {
+		t.Errorf("FromGRPC(ToGRPC()).MetaKeys()\n exp: %q\n got: %q\n", err.MetaKeys(), got.MetaKeys())
+	}
+}
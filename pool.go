@@ -0,0 +1,49 @@
+package errors
+
+import "sync"
+
+var pool = sync.Pool{
+	New: func() interface{} {
+		return &Error{}
+	},
+}
+
+// Acquire returns an Error from a pool, for hot paths creating many short
+// lived errors. The error must be given back with Release once it is no
+// longer referenced, e.g. after writing it to the response.
+//
+// The writers, WriteHTTP, WriteHTTPRequest, WriteHTMLRequest, WriteOAuth2 and
+// the grpc interceptors, are done with the error when they return, and
+// Report hands the sinks a copy of it, so it can be released right after
+// them. It must not be released while the caller still references it, e.g.
+// wrapped into another error or passed to a goroutine.
+//
+//	e := errors.Acquire(errors.StatusTooManyRequests, "slow down")
+//	errors.WriteHTTP(w, e)
+//	errors.Release(e)
+func Acquire(code Code, msg string, setters ...errorParamsSetter) *Error {
+	e := pool.Get().(*Error)
+	e.pooled = true
+	e.StatusCode = code
+	e.Message = msg
+	for _, fn := range setters {
+		fn(e)
+	}
+	e.applyCodeDefaults()
+	e.breakCycle()
+	e.truncateMessage()
+	return e
+}
+
+// Release resets e and puts it back in the pool. e must not be used after
+// calling Release. Its Meta is left untouched, as it may be shared with the
+// caller, and a new one is allocated by the next Acquire setting meta. It
+// does nothing if e is nil.
+func Release(e *Error) {
+	if e == nil {
+		return
+	}
+
+	*e = Error{}
+	pool.Put(e)
+}
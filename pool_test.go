@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcquireRelease(t *testing.T) {
+	shared := Meta{"limit": 10}
+
+	e := Acquire(StatusTooManyRequests, "slow down", SetMeta(shared))
+	if !e.Equal(RateLimit("slow down", SetMeta(Meta{"limit": 10}))) {
+		t.Errorf("Acquire()\n%s", Diff(RateLimit("slow down", SetMeta(Meta{"limit": 10})), e))
+	}
+
+	Release(e)
+	if len(shared) != 1 {
+		t.Errorf("Release() modified the meta given to SetMeta: %v", shared)
+	}
+
+	e = Acquire(StatusBadRequest, "")
+	if !e.Equal(BadRequest("")) {
+		t.Errorf("Acquire() after Release()\n%s", Diff(BadRequest(""), e))
+	}
+	Release(e)
+
+	meta := Meta{"limit": 10}
+	e = Acquire(StatusTooManyRequests, "slow down", SetMetaNoCopy(meta))
+	Release(e)
+	if len(meta) != 1 {
+		t.Errorf("Release() cleared the meta adopted with SetMetaNoCopy: %v", meta)
+	}
+
+	Release(nil)
+}
+
+func TestReleaseReported(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	sink, agg := &testSink{}, NewAggregator(time.Minute)
+	AddSink(sink)
+	AddSink(agg)
+
+	e := Acquire(StatusTooManyRequests, "slow down", SetMeta(Meta{"limit": 10}))
+	Report(e)
+	agg.Report(e)
+	Release(e)
+
+	if got := sink.reported[0]; got == e || got.Message != "slow down" || got.Meta["limit"] != 10 {
+		t.Errorf("Report() of a pooled error\n exp: a copy of it\n got: %v\n", got)
+	}
+	if got := agg.Groups()[0].Sample; got == e || got.Message != "slow down" {
+		t.Errorf("Aggregator.Report() of a pooled error\n exp: a copy of it\n got: %v\n", got)
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		e := RateLimit("slow down", SetMeta(Meta{"limit": 10}))
+		_ = e.Error()
+	}
+}
+
+func BenchmarkAcquire(b *testing.B) {
+	b.ReportAllocs()
+	meta := Meta{"limit": 10}
+	for i := 0; i < b.N; i++ {
+		e := Acquire(StatusTooManyRequests, "slow down", SetMeta(meta))
+		_ = e.Error()
+		Release(e)
+	}
+}
@@ -0,0 +1,61 @@
+package errors
+
+// Priority tells how urgently an error needs attention, so alert routing
+// lives with the error definition.
+type Priority string
+
+// Priorities
+const (
+	// PriorityPage errors page the on-call engineer.
+	PriorityPage Priority = "page"
+	// PriorityTicket errors open a ticket to be handled in working hours.
+	PriorityTicket Priority = "ticket"
+	// PriorityIgnore errors need no action, e.g. client errors.
+	PriorityIgnore Priority = "ignore"
+)
+
+// SetPriority sets the Priority of the error, overriding the default of its
+// code.
+//
+//	errors.InternalServerFromError(err, "stale cache", errors.SetPriority(errors.PriorityTicket))
+func SetPriority(p Priority) errorParamsSetter {
+	return func(e *Error) {
+		e.Priority = p
+	}
+}
+
+// PriorityOf returns the Priority of the *Error in the chain of err, or the
+// default of its code, as resolved by CodeOf. It returns an empty Priority
+// if err is nil.
+func PriorityOf(err error) Priority {
+	if err == nil {
+		return ""
+	}
+	for cur, i := err, 0; cur != nil && i < maxChainDepth; cur, i = unwrap(cur), i+1 {
+		if e, ok := cur.(*Error); ok && len(e.Priority) > 0 {
+			return e.Priority
+		}
+	}
+	return CodeOf(err).priority()
+}
+
+// priority returns the registered Priority of the code, or its default.
+func (c Code) priority() Priority {
+	if info, ok := lookupCode(c); ok && len(info.Priority) > 0 {
+		return info.Priority
+	}
+	return defaultPriority(c.httpStatus())
+}
+
+// defaultPriority returns the default Priority of the codes with the given
+// http status: internal_server class errors page, request_timeout and
+// too_many_requests ones open a ticket and the rest are ignored.
+func defaultPriority(httpStatus int) Priority {
+	switch {
+	case httpStatus >= 500:
+		return PriorityPage
+	case httpStatus == 408 || httpStatus == 429:
+		return PriorityTicket
+	}
+	return PriorityIgnore
+}
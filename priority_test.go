@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPriorityOf(t *testing.T) {
+	tests := []struct {
+		err error
+		exp Priority
+	}{
+		{nil, ""},
+		{NotFound("no account"), PriorityIgnore},
+		{RateLimit("slow down"), PriorityTicket},
+		{InternalServer("db down"), PriorityPage},
+		{InternalServer("stale cache", SetPriority(PriorityTicket)), PriorityTicket},
+		{fmt.Errorf("get account: %w", BadRequest("", SetPriority(PriorityPage))), PriorityPage},
+		{errors.New("boom"), PriorityPage},
+	}
+
+	for _, tt := range tests {
+		if got := PriorityOf(tt.err); got != tt.exp {
+			t.Errorf("PriorityOf(%v)\n exp: %q\n got: %q\n", tt.err, tt.exp, got)
+		}
+	}
+}
+
+func TestPriorityGRPC(t *testing.T) {
+	e := InternalServer("stale cache", SetPriority(PriorityTicket))
+	if got := FromGRPC(e.ToGRPC()); got.Priority != PriorityTicket {
+		t.Errorf("FromGRPC(ToGRPC()) priority\n exp: %q\n got: %q\n", PriorityTicket, got.Priority)
+	}
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Meta keys of the quota of rate_limit errors, see SetQuota.
+const (
+	LimitKey     = "limit"     // requests allowed per window
+	RemainingKey = "remaining" // requests left in the current window
+	ResetAtKey   = "reset_at"  // time the window resets, RFC 3339
+	ScopeKey     = "scope"     // what the quota applies to, e.g. "user"
+)
+
+// Quota is the rate limit quota of a rate_limit error.
+type Quota struct {
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+	Scope     string
+}
+
+// SetQuota sets the quota into the Meta of the error under the well-known
+// keys. Zero ResetAt and empty Scope are not set.
+//
+//	errors.RateLimit("slow down", errors.SetQuota(errors.Quota{Limit: 100, ResetAt: reset, Scope: "user"}))
+func SetQuota(q Quota) errorParamsSetter {
+	m := Meta{LimitKey: q.Limit, RemainingKey: q.Remaining}
+	if !q.ResetAt.IsZero() {
+		m[ResetAtKey] = q.ResetAt.UTC().Format(time.RFC3339)
+	}
+	if len(q.Scope) > 0 {
+		m[ScopeKey] = q.Scope
+	}
+	return SetMeta(m)
+}
+
+// Quota returns the quota set with SetQuota, also when the error was decoded
+// from the wire. It reports false if there is no quota.
+func (e *Error) Quota() (Quota, bool) {
+	limit, ok := metaInt(e.Meta[LimitKey])
+	if !ok {
+		return Quota{}, false
+	}
+
+	q := Quota{Limit: limit}
+	q.Remaining, _ = metaInt(e.Meta[RemainingKey])
+	q.Scope, _ = e.Meta[ScopeKey].(string)
+
+	switch v := e.Meta[ResetAtKey].(type) {
+	case time.Time:
+		q.ResetAt = v
+	case string:
+		q.ResetAt, _ = time.Parse(time.RFC3339, v)
+	}
+
+	return q, true
+}
+
+// metaInt returns the integer value of a meta value, which is a float64 or
+// json.Number when decoded from the wire.
+func metaInt(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	}
+	return 0, false
+}
+
+// quotaFailure returns the errdetails.QuotaFailure of the Quota of a
+// rate_limit error, nil if it has none.
+func (e *Error) quotaFailure() *errdetails.QuotaFailure {
+	if e.StatusCode != StatusTooManyRequests {
+		return nil
+	}
+	q, ok := e.Quota()
+	if !ok {
+		return nil
+	}
+
+	desc := fmt.Sprintf("limit of %d requests exceeded", q.Limit)
+	if !q.ResetAt.IsZero() {
+		desc += ", resets at " + q.ResetAt.UTC().Format(time.RFC3339)
+	}
+
+	return &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: q.Scope, Description: desc}},
+	}
+}
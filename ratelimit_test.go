@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQuota(t *testing.T) {
+	reset := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	q := Quota{Limit: 100, Remaining: 0, ResetAt: reset, Scope: "user"}
+
+	e := RateLimit("slow down", SetQuota(q))
+
+	exp := Meta{LimitKey: 100, RemainingKey: 0, ResetAtKey: "2026-10-15T12:00:00Z", ScopeKey: "user"}
+	if !reflect.DeepEqual(e.Meta, exp) {
+		t.Errorf("SetQuota() meta\n exp: %v\n got: %v\n", exp, e.Meta)
+	}
+
+	tests := []struct {
+		err *Error
+		exp Quota
+		ok  bool
+	}{
+		{e, q, true},
+		{FromGRPC(e.ToGRPC()), q, true},
+		{RateLimit("slow down", SetQuota(Quota{Limit: 10, Remaining: 3})), Quota{Limit: 10, Remaining: 3}, true},
+		{RateLimit("slow down"), Quota{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.err.Quota()
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("Quota()\n exp: %+v %v\n got: %+v %v\n", tt.exp, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestQuotaFailureDetail(t *testing.T) {
+	reset := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	e := RateLimit("slow down", SetQuota(Quota{Limit: 100, ResetAt: reset, Scope: "user:u1"}))
+
+	var failure *errdetails.QuotaFailure
+	for _, d := range e.GRPCStatus().Details() {
+		if d, ok := d.(*errdetails.QuotaFailure); ok {
+			failure = d
+		}
+	}
+
+	exp := "limit of 100 requests exceeded, resets at 2026-10-15T12:00:00Z"
+	if failure == nil || len(failure.Violations) != 1 || failure.Violations[0].Subject != "user:u1" || failure.Violations[0].Description != exp {
+		t.Errorf("GRPCStatus() QuotaFailure\n exp: user:u1 %s\n got: %v\n", exp, failure)
+	}
+
+	foreign, _ := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: "project:p1", Description: "daily limit"}},
+	})
+	if got := FromGRPC(foreign.Err()); got.StatusCode != StatusTooManyRequests || got.Meta[ScopeKey] != "project:p1" {
+		t.Errorf("FromGRPC(QuotaFailure)\n exp: rate_limit scope=project:p1\n got: %v\n", got)
+	}
+}
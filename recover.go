@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// InternalMeta keys set by Recover
+const (
+	PanicKey = "panic"
+	StackKey = "stack"
+)
+
+// Keys of the panic value set under PanicKey, see FromPanic.
+const (
+	PanicTypeKey  = "type"  // go type of the value, e.g. "runtime.boundsError"
+	PanicValueKey = "value" // the value, or its description
+)
+
+// FromPanic returns the internal_server error of a recovered panic, with the
+// panic value and the stack in its InternalMeta. The panic value is set under
+// PanicKey as a Meta with its type, so panics can be grouped by type, and
+// value: the description of errors and fmt.Stringer values, json
+// serializable values as is and the fmt description of the rest, including
+// the values serialized as an empty object or null, e.g. structs with only
+// unexported fields. Panics with an error keep it as InternalError.
+//
+//	defer func() {
+//		if v := recover(); v != nil {
+//			err = errors.FromPanic(v)
+//		}
+//	}()
+func FromPanic(v interface{}) *Error {
+	var cause error
+	value := v
+	switch p := v.(type) {
+	case error:
+		cause = fmt.Errorf("panic: %w", p)
+		value = p.Error()
+	case fmt.Stringer:
+		value = p.String()
+	case string:
+	default:
+		// {} and null would lose the value, e.g. of unexported fields
+		if b, err := json.Marshal(v); err != nil || string(b) == "{}" || string(b) == "null" {
+			value = fmt.Sprintf("%+v", v)
+		}
+	}
+	if cause == nil {
+		cause = fmt.Errorf("panic: %v", v)
+	}
+
+	return InternalServerFromError(cause, UnexpectedMsg, SetInternalMeta(Meta{
+		PanicKey: Meta{PanicTypeKey: fmt.Sprintf("%T", v), PanicValueKey: value},
+		StackKey: string(debug.Stack()),
+	}))
+}
+
+// Recover returns a net/http middleware that recovers panics in next,
+// writing the internal_server error of FromPanic and reporting it to the
+// sinks.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			e := FromPanic(v)
+
+			// sanitized 5xx errors are already reported by the writer
+			if ConfigFrom(r.Context()).Mode != Sanitized {
+				Report(e)
+			}
+			WriteHTTPRequest(w, r, e)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	sink := &testSink{}
+	AddSink(sink)
+	defer UpdateConfig(func(c *Config) { c.Sinks = nil })
+
+	h := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := FromHTTPResponse(rec.Result()); got.StatusCode != StatusInternalServerError || got.Message != UnexpectedMsg {
+		t.Errorf("Recover() response\n exp: %v\n got: %v\n", InternalServer(UnexpectedMsg), got)
+	}
+
+	if len(sink.reported) != 1 {
+		t.Fatalf("Recover() reported %d errors, exp 1", len(sink.reported))
+	}
+
+	e := sink.reported[0]
+	if !reflect.DeepEqual(e.InternalMeta[PanicKey], Meta{PanicTypeKey: "string", PanicValueKey: "boom"}) || !strings.Contains(e.InternalMeta[StackKey].(string), "TestRecover") {
+		t.Errorf("Recover() unexpected internal meta %v", e.InternalMeta)
+	}
+}
+
+type testPanic struct {
+	Account string `json:"account"`
+}
+
+type unexportedPanic struct{ a int }
+
+func TestFromPanic(t *testing.T) {
+	var boundsErr error
+	func() {
+		defer func() { boundsErr = recover().(error) }()
+		var s []int
+		_ = s[1]
+	}()
+
+	tests := []struct {
+		v     interface{}
+		panic Meta
+		cause bool
+	}{
+		{"boom", Meta{PanicTypeKey: "string", PanicValueKey: "boom"}, false},
+		{boundsErr, Meta{PanicTypeKey: "runtime.boundsError", PanicValueKey: boundsErr.Error()}, true},
+		{testPanic{"a1"}, Meta{PanicTypeKey: "errors.testPanic", PanicValueKey: testPanic{"a1"}}, false},
+		{complex(1, 2), Meta{PanicTypeKey: "complex128", PanicValueKey: "(1+2i)"}, false},
+		{42, Meta{PanicTypeKey: "int", PanicValueKey: 42}, false},
+		{unexportedPanic{1}, Meta{PanicTypeKey: "errors.unexportedPanic", PanicValueKey: "{a:1}"}, false},
+		{&unexportedPanic{2}, Meta{PanicTypeKey: "*errors.unexportedPanic", PanicValueKey: "&{a:2}"}, false},
+		{(*testPanic)(nil), Meta{PanicTypeKey: "*errors.testPanic", PanicValueKey: "<nil>"}, false},
+	}
+
+	for _, tt := range tests {
+		e := FromPanic(tt.v)
+		got := e.InternalMeta[PanicKey].(Meta)
+		if !reflect.DeepEqual(got, tt.panic) {
+			t.Errorf("FromPanic(%v)\n exp: %v\n got: %v\n", tt.v, tt.panic, got)
+		}
+		if cause := stderrors.Is(e, boundsErr); cause != tt.cause {
+			t.Errorf("errors.Is(FromPanic(%v), panic value)\n exp: %t\n got: %t\n", tt.v, tt.cause, cause)
+		}
+		if e.StatusCode != StatusInternalServerError || len(e.InternalMeta[StackKey].(string)) == 0 {
+			t.Errorf("FromPanic(%v) = %v, expected an internal_server error with stack", tt.v, e)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CodeInfo describes a status code.
+type CodeInfo struct {
+	Code       Code   `json:"code"`
+	ID         string `json:"error_id"`    // error_id, e.g. "not_found"
+	HTTPStatus int    `json:"http_status"` // status used by the http writers
+	Message    string `json:"msg"`         // default message, e.g. "not found"
+	Retryable  bool   `json:"retryable"`   // whether the operation may succeed if retried
+
+	// Priority of the errors of the code, derived from HTTPStatus if empty,
+	// see PriorityOf.
+	Priority Priority `json:"priority"`
+
+	// Defaults applied by the constructors, see SetCodeDefaults.
+	Severity Severity `json:"severity,omitempty"`
+	HelpURL  string   `json:"help_url,omitempty"`
+	Meta     Meta     `json:"meta,omitempty"`
+}
+
+var registry = struct {
+	sync.RWMutex
+	codes map[Code]CodeInfo
+}{
+	codes: map[Code]CodeInfo{},
+}
+
+func init() {
+	for _, code := range []Code{
+		StatusBadRequest,
+		StatusUnauthorized,
+		StatusPaymentRequired,
+		StatusForbidden,
+		StatusNotFound,
+		StatusMethodNotAllowed,
+		StatusNotAcceptable,
+		StatusRequestTimeout,
+		StatusUnprocessableEntity,
+		StatusTooManyRequests,
+		StatusClientClosedRequest,
+		StatusInternalServerError,
+	} {
+		msg := http.StatusText(int(code))
+		if len(msg) == 0 {
+			msg = strings.ReplaceAll(code.String(), "_", " ")
+		}
+
+		registry.codes[code] = internInfo(CodeInfo{
+			Code:       code,
+			ID:         code.String(),
+			HTTPStatus: int(code),
+			Message:    strings.ToLower(msg),
+			Retryable:  code == StatusRequestTimeout || code == StatusTooManyRequests || code == StatusInternalServerError,
+			Priority:   defaultPriority(int(code)),
+		})
+	}
+}
+
+// RegisterCode registers a custom status code. It fails if the code is
+// already registered.
+//
+//	const StatusInsufficientFunds errors.Code = 1001
+//
+//	errors.RegisterCode(errors.CodeInfo{
+//		Code:       StatusInsufficientFunds,
+//		ID:         "insufficient_funds",
+//		HTTPStatus: http.StatusPaymentRequired,
+//	})
+func RegisterCode(info CodeInfo) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.codes[info.Code]; ok {
+		return fmt.Errorf("errors: code %d already registered", info.Code)
+	}
+	if len(info.Priority) == 0 {
+		info.Priority = defaultPriority(info.HTTPStatus)
+	}
+	registry.codes[info.Code] = internInfo(info)
+	ids.Delete(info.Code)
+
+	return nil
+}
+
+// maxCachedIDs bounds the number of unregistered codes whose id is cached,
+// since codes may come from the wire.
+const maxCachedIDs = 1024
+
+var (
+	ids       sync.Map // Code -> string
+	cachedIDs int32
+)
+
+// id returns the registered id of the code, or its string representation.
+// Ids are cached per code.
+func (c Code) id() string {
+	if id, ok := ids.Load(c); ok {
+		return id.(string)
+	}
+
+	id := fmt.Sprint(c)
+	info, registered := lookupCode(c)
+	if registered && len(info.ID) > 0 {
+		id = info.ID
+	}
+
+	if registered || atomic.AddInt32(&cachedIDs, 1) <= maxCachedIDs {
+		ids.Store(c, id)
+	}
+	return id
+}
+
+// lookupCode returns the registered information of the code.
+func lookupCode(c Code) (CodeInfo, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	info, ok := registry.codes[c]
+	return info, ok
+}
+
+// HTTPStatusOf returns the http status matching the code of err, as
+// resolved by CodeOf. It returns http.StatusOK if err is nil.
+func HTTPStatusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return CodeOf(err).httpStatus()
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatusOf(t *testing.T) {
+	const insufficientFunds Code = 1001
+
+	if err := RegisterCode(CodeInfo{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: http.StatusPaymentRequired}); err != nil {
+		t.Fatalf("RegisterCode() unexpected error: %v", err)
+	}
+	defer func() {
+		delete(registry.codes, insufficientFunds)
+		ids.Delete(insufficientFunds)
+	}()
+
+	if err := RegisterCode(CodeInfo{Code: StatusNotFound}); err == nil {
+		t.Errorf("RegisterCode(%d) expected error for a registered code", StatusNotFound)
+	}
+
+	tests := []struct {
+		err error
+		exp int
+	}{
+		{nil, http.StatusOK},
+		{NotFound(""), http.StatusNotFound},
+		{New(insufficientFunds, ""), http.StatusPaymentRequired},
+		{New(4, ""), http.StatusInternalServerError},
+		{status.Error(codes.PermissionDenied, ""), http.StatusForbidden},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := HTTPStatusOf(tt.err); got != tt.exp {
+			t.Errorf("HTTPStatusOf(%v)\n exp: %d\n got: %d\n", tt.err, tt.exp, got)
+		}
+	}
+
+	if got := New(insufficientFunds, "").ErrorID(); got != "insufficient_funds" {
+		t.Errorf("ErrorID()\n exp: %q\n got: %q\n", "insufficient_funds", got)
+	}
+}
+
+func BenchmarkErrorID(b *testing.B) {
+	b.ReportAllocs()
+	e := NotFound("")
+	for i := 0; i < b.N; i++ {
+		_ = e.ErrorID()
+	}
+}
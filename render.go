@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// RenderTemplate renders the error with the given text/template, e.g. for
+// notification emails and chat messages. The template is executed with the
+// error as data, so its fields, Meta and methods like ErrorID and
+// Fingerprint are available, as well as the localization functions like
+// money. It returns Error() if the template is invalid or fails.
+//
+//	e.RenderTemplate(`Payment of {{money .Meta.amount .Meta.currency}} failed: {{.Message}} ({{.ErrorID}})`)
+func (e *Error) RenderTemplate(tpl string) string {
+	t, err := template.New("error").Funcs(templateFuncs(DefaultLanguage)).Option("missingkey=zero").Parse(tpl)
+	if err != nil {
+		return e.Error()
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, e); err != nil {
+		return e.Error()
+	}
+	return buf.String()
+}
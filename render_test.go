@@ -0,0 +1,24 @@
+package errors
+
+import "testing"
+
+func TestRenderTemplate(t *testing.T) {
+	e := Delinquent("insufficient funds", SetMeta(Meta{"amount": 1250, "currency": "USD", "account": "a1"}))
+
+	tests := []struct {
+		tpl string
+		exp string
+	}{
+		{`{{.Message}} ({{.ErrorID}}, {{.StatusCode}})`, "insufficient funds (delinquent, delinquent)"},
+		{`Account {{.Meta.account}} is missing {{money .Meta.amount .Meta.currency}}`, "Account a1 is missing USD 12.50"},
+		{`{{.Meta.missing}}`, "<no value>"},
+		{`{{.Unknown}}`, e.Error()},
+		{`{{.Message`, e.Error()},
+	}
+
+	for _, tt := range tests {
+		if got := e.RenderTemplate(tt.tpl); got != tt.exp {
+			t.Errorf("RenderTemplate(%q)\n exp: %q\n got: %q\n", tt.tpl, tt.exp, got)
+		}
+	}
+}
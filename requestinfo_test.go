@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestInfoDetail(t *testing.T) {
+	e := NotFound("no account", SetMeta(Meta{RequestIDKey: "r1", ServingDataKey: "accounts-7f9c v1.2.3"}))
+
+	var info *errdetails.RequestInfo
+	for _, d := range e.GRPCStatus().Details() {
+		if d, ok := d.(*errdetails.RequestInfo); ok {
+			info = d
+		}
+	}
+	if info == nil || info.RequestId != "r1" || info.ServingData != "accounts-7f9c v1.2.3" {
+		t.Errorf("GRPCStatus() RequestInfo\n exp: r1 accounts-7f9c v1.2.3\n got: %v\n", info)
+	}
+
+	foreign, _ := status.New(codes.NotFound, "not found").WithDetails(
+		&errdetails.ErrorInfo{Reason: "NOT_FOUND"},
+		&errdetails.RequestInfo{RequestId: "r2"},
+	)
+
+	tests := []struct {
+		err error
+		exp Meta
+	}{
+		{e.ToGRPC(), e.Meta},
+		{foreign.Err(), Meta{RequestIDKey: "r2"}},
+	}
+
+	for _, tt := range tests {
+		if got := FromGRPC(tt.err).Meta; !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("FromGRPC(%v).Meta\n exp: %v\n got: %v\n", tt.err, tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package errors
+
+import "time"
+
+// RetryAfterKey is the Meta key holding the time callers should wait before
+// retrying, as a duration string like "1.5s".
+const RetryAfterKey = "retry_after"
+
+// SetRetryAfter sets the time callers should wait before retrying into the
+// Meta of the error.
+//
+//	errors.RateLimit("slow down", errors.SetRetryAfter(30*time.Second))
+func SetRetryAfter(d time.Duration) errorParamsSetter {
+	return SetMeta(Meta{RetryAfterKey: d.String()})
+}
+
+// RetryAfter returns the time set with SetRetryAfter, also when the error
+// was decoded from the wire. It reports false if there is none.
+func (e *Error) RetryAfter() (time.Duration, bool) {
+	switch v := e.Meta[RetryAfterKey].(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		d, err := time.ParseDuration(v)
+		return d, err == nil
+	}
+	return 0, false
+}
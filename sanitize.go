@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Well known Meta keys
+const (
+	FingerprintKey = "fingerprint"
+	RequestIDKey   = "request_id"
+	ServingDataKey = "serving_data" // e.g. the host and version serving the request
+)
+
+// Fingerprint returns an identifier shared by errors with the same code and
+// message, used to group and reference them.
+func (e *Error) Fingerprint() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", e.StatusCode, e.Message)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Sanitize returns the error as it can be shown to external clients. Errors
+// of the internal_server class (5xx) are replaced by a generic error carrying
+// only the fingerprint and request id, other errors are returned unchanged.
+func (e *Error) Sanitize() *Error {
+	if e.StatusCode < StatusInternalServerError || e.StatusCode > 599 {
+		return e
+	}
+
+	meta := Meta{FingerprintKey: e.Fingerprint()}
+	if id, ok := e.Meta[RequestIDKey]; ok {
+		meta[RequestIDKey] = id
+	}
+
+	return &Error{
+		StatusCode: e.StatusCode,
+		Message:    UnexpectedMsg,
+		Meta:       meta,
+	}
+}
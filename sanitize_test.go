@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWriteHTTPSanitized(t *testing.T) {
+	SetMode(Sanitized)
+	sink := &testSink{}
+	AddSink(sink)
+	defer func() {
+		SetMode(Production)
+		UpdateConfig(func(c *Config) { c.Sinks = nil })
+	}()
+
+	internal := InternalServerFromError(errors.New("pq: timeout"), "select * from accounts", SetMeta(Meta{"request_id": "r1", "account": "a1"}))
+
+	tests := []struct {
+		err *Error
+		exp *Error
+	}{
+		{NotFound("no account", SetMeta(Meta{"account": "a1"})), NotFound("no account", SetMeta(Meta{"account": "a1"}))},
+		{internal, InternalServer(UnexpectedMsg, SetMeta(Meta{"request_id": "r1", "fingerprint": internal.Fingerprint()}))},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		WriteHTTP(rec, tt.err)
+
+		got := FromHTTPResponse(rec.Result())
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("WriteHTTP(%v)\n exp: %v\n got: %v\n", tt.err, tt.exp, got)
+		}
+	}
+
+	if len(sink.reported) != 1 || sink.reported[0] != internal {
+		t.Errorf("WriteHTTP sinks\n exp: [%v]\n got: %v\n", internal, sink.reported)
+	}
+}
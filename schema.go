@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"encoding/json"
+)
+
+// SchemaID is the $id of the document returned by JSONSchema.
+const SchemaID = "https://github.com/Finciero/errors/error.schema.json"
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document describing the
+// error object written by MarshalJSON with the current naming style. The
+// ids and codes of the registered codes are listed as enums.
+func JSONSchema() ([]byte, error) {
+	catalog := Catalog()
+
+	errorIDs := make([]string, len(catalog))
+	codes := make([]Code, len(catalog))
+	for i, info := range catalog {
+		errorIDs[i] = info.ID
+		codes[i] = info.Code
+	}
+
+	field := func(name string) string {
+		if currentNaming() == CamelCase {
+			return toCamel(name)
+		}
+		return name
+	}
+	object := map[string]interface{}{"type": "object"}
+	str := map[string]interface{}{"type": "string"}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"$id":      SchemaID,
+		"title":    "Error",
+		"type":     "object",
+		"required": []string{field("error_id"), field("status_code")},
+		"properties": map[string]interface{}{
+			"meta":                    object,
+			"msg":                     str,
+			field("user_msg"):         str,
+			field("error_id"):         map[string]interface{}{"type": "string", "enum": errorIDs},
+			field("status_code"):      map[string]interface{}{"type": "integer", "enum": codes},
+			field("fallback_allowed"): map[string]interface{}{"type": "boolean"},
+			field("internal_error"):   str,
+			field("internal_meta"):    object,
+			"stack":                   map[string]interface{}{"type": "array", "items": str},
+			"errors":                  map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#"}},
+			"suppressed":              map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#"}},
+		},
+	}, "", "  ")
+}
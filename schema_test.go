@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	const insufficientFunds Code = 1001
+
+	RegisterCode(CodeInfo{Code: insufficientFunds, ID: "insufficient_funds", HTTPStatus: 402})
+	defer func() {
+		delete(registry.codes, insufficientFunds)
+		ids.Delete(insufficientFunds)
+	}()
+
+	b, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() unexpected error: %v", err)
+	}
+
+	var schema struct {
+		Required   []string
+		Properties map[string]struct {
+			Enum []interface{}
+		}
+	}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("JSONSchema() invalid json: %v", err)
+	}
+
+	if exp := []string{"error_id", "status_code"}; !reflect.DeepEqual(schema.Required, exp) {
+		t.Errorf("JSONSchema() required\n exp: %v\n got: %v\n", exp, schema.Required)
+	}
+
+	ids := schema.Properties["error_id"].Enum
+	if len(ids) != len(Catalog()) || ids[0] != "bad_request" || ids[len(ids)-1] != "insufficient_funds" {
+		t.Errorf("JSONSchema() error_id enum\n exp: registered ids\n got: %v\n", ids)
+	}
+}
+
+func TestJSONSchemaProperties(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	SetMode(Debug)
+	CaptureStacks(true)
+
+	e := InternalServerFromError(errors.New("db down"), "unexpected", SetMeta(Meta{"hi": "ho"}), SetInternalMeta(Meta{"db": "main"}), func(e *Error) {
+		e.UserMessage = "try later"
+		e.FallbackAllowed = true
+	})
+
+	for _, naming := range []Naming{SnakeCase, CamelCase} {
+		SetNaming(naming)
+
+		b, err := JSONSchema()
+		if err != nil {
+			t.Fatalf("JSONSchema() unexpected error: %v", err)
+		}
+		var schema struct {
+			Properties map[string]interface{}
+		}
+		if err := json.Unmarshal(b, &schema); err != nil {
+			t.Fatalf("JSONSchema() invalid json: %v", err)
+		}
+
+		var obj map[string]interface{}
+		b, _ = json.Marshal(e)
+		json.Unmarshal(b, &obj)
+		for key := range obj {
+			if _, ok := schema.Properties[key]; !ok {
+				t.Errorf("JSONSchema() with naming %d misses property %q of %s", naming, key, b)
+			}
+		}
+	}
+}
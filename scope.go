@@ -0,0 +1,92 @@
+package errors
+
+import "context"
+
+type configKey struct{}
+
+// WithConfig returns a copy of ctx overriding the package configuration with
+// fn for the errors created with SetContextConfig, written by
+// WriteHTTPRequest or returned by the grpc interceptors under it. The
+// overrides of the parent contexts are applied first.
+//
+//	func support(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			if r.Header.Get("X-Support-Token") == token {
+//				r = r.WithContext(errors.WithConfig(r.Context(), func(c *errors.Config) {
+//					c.Mode = errors.Debug
+//				}))
+//			}
+//			next.ServeHTTP(w, r)
+//		})
+//	}
+func WithConfig(ctx context.Context, fn func(*Config)) context.Context {
+	overrides := overridesOf(ctx)
+	return context.WithValue(ctx, configKey{}, append(overrides[:len(overrides):len(overrides)], fn))
+}
+
+// ConfigFrom returns the package configuration with the overrides of ctx
+// applied.
+func ConfigFrom(ctx context.Context) Config {
+	c := CurrentConfig()
+	for _, fn := range overridesOf(ctx) {
+		fn(&c)
+	}
+	return c
+}
+
+// overridesOf returns the configuration overrides of ctx.
+func overridesOf(ctx context.Context) []func(*Config) {
+	if ctx == nil {
+		return nil
+	}
+	overrides, _ := ctx.Value(configKey{}).([]func(*Config))
+	return overrides
+}
+
+// configOf returns the configuration of ctx, or nil if it has no overrides.
+func configOf(ctx context.Context) *Config {
+	if len(overridesOf(ctx)) == 0 {
+		return nil
+	}
+	c := ConfigFrom(ctx)
+	return &c
+}
+
+// SetContextConfig makes the error use the configuration of ctx, see
+// WithConfig, instead of the package one: its stack is captured and its
+// message truncated accordingly, and it is serialized, scrubbed, reported
+// and logged with it.
+func SetContextConfig(ctx context.Context) errorParamsSetter {
+	return func(e *Error) {
+		c := configOf(ctx)
+		if c == nil {
+			return
+		}
+		e.config = c
+		if len(e.stack) == 0 && e.sampleStack(c) {
+			e.stack = callers(3)
+		}
+	}
+}
+
+// withConfig returns a copy of e using the configuration of ctx, or e itself
+// if ctx has no overrides.
+func (e *Error) withConfig(ctx context.Context) *Error {
+	c := configOf(ctx)
+	if c == nil {
+		return e
+	}
+
+	copied := *e
+	copied.config = c
+	return &copied
+}
+
+// cfg returns the configuration of the error, the package one unless set
+// from a context.
+func (e *Error) cfg() *Config {
+	if e.config != nil {
+		return e.config
+	}
+	return loadConfig()
+}
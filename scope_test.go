@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithConfig(t *testing.T) {
+	ctx := WithConfig(context.Background(), func(c *Config) { c.Mode = Debug })
+	ctx = WithConfig(ctx, func(c *Config) { c.MaxMessageLength = 8 })
+
+	if got := ConfigFrom(ctx); got.Mode != Debug || got.MaxMessageLength != 8 {
+		t.Errorf("ConfigFrom()\n exp: mode %d, max length 8\n got: mode %d, max length %d\n", Debug, got.Mode, got.MaxMessageLength)
+	}
+	if got := CurrentMode(); got != Production {
+		t.Errorf("CurrentMode() after WithConfig()\n exp: %d\n got: %d\n", Production, got)
+	}
+
+	e := InternalServerFromError(errors.New("boom"), "unexpected error", SetContextConfig(ctx))
+	if e.Message != "unexp…" {
+		t.Errorf("SetContextConfig() message\n exp: %q\n got: %q\n", "unexp…", e.Message)
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+	var decoded Error
+	if err := json.Unmarshal(b, &decoded); err != nil || decoded.InternalError == nil {
+		t.Errorf("Marshal() with SetContextConfig(debug)\n exp: internal error\n got: %s (%v)\n", b, err)
+	}
+}
+
+func TestWriteHTTPRequestConfig(t *testing.T) {
+	internal := InternalServerFromError(errors.New("boom"), UnexpectedMsg)
+
+	tests := []struct {
+		debug bool
+		exp   bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.debug {
+			r = r.WithContext(WithConfig(r.Context(), func(c *Config) { c.Mode = Debug }))
+		}
+
+		rec := httptest.NewRecorder()
+		WriteHTTPRequest(rec, r, internal)
+
+		if got := FromHTTPResponse(rec.Result()).InternalError != nil; got != tt.exp {
+			t.Errorf("WriteHTTPRequest() debug %t internal error\n exp: %t\n got: %t\n", tt.debug, tt.exp, got)
+		}
+	}
+
+	ctx := WithConfig(context.Background(), func(c *Config) { c.Mode = Debug })
+	if got := FromGRPC(internal.ToGRPCContext(ctx)); got.InternalError == nil {
+		t.Errorf("ToGRPCContext() with debug config\n exp: internal error\n got: %v\n", got)
+	}
+}
@@ -0,0 +1,161 @@
+package errors
+
+import (
+	"errors"
+	"regexp"
+)
+
+// Redacted replaces the values removed by scrubbers.
+const Redacted = "[REDACTED]"
+
+// Scrubber removes sensitive data from errors. Scrub must not modify the
+// given error, but return a scrubbed copy instead.
+type Scrubber interface {
+	Scrub(*Error) *Error
+}
+
+// ScrubberFunc is an adapter to allow the use of ordinary functions as
+// Scrubber.
+type ScrubberFunc func(*Error) *Error
+
+// Scrub calls f(e).
+func (f ScrubberFunc) Scrub(e *Error) *Error {
+	return f(e)
+}
+
+// AddScrubber registers s to be applied to every error before it is
+// serialized or reported.
+func AddScrubber(s Scrubber) {
+	UpdateConfig(func(c *Config) { c.Scrubbers = append(c.Scrubbers, s) })
+}
+
+// scrub applies the scrubbers of the error configuration to e.
+func scrub(e *Error) *Error {
+	for _, s := range e.cfg().Scrubbers {
+		e = s.Scrub(e)
+	}
+	return e
+}
+
+// Scrubbers composes the given scrubbers, applying them in order.
+func Scrubbers(list ...Scrubber) Scrubber {
+	return ScrubberFunc(func(e *Error) *Error {
+		for _, s := range list {
+			e = s.Scrub(e)
+		}
+		return e
+	})
+}
+
+// ScrubKeys returns a Scrubber redacting the values of the given Meta and
+// InternalMeta keys.
+func ScrubKeys(keys ...string) Scrubber {
+	return ScrubberFunc(func(e *Error) *Error {
+		scrubbed := e.copy()
+		for _, key := range keys {
+			if _, ok := scrubbed.Meta[key]; ok {
+				scrubbed.Meta[key] = Redacted
+			}
+			if _, ok := scrubbed.InternalMeta[key]; ok {
+				scrubbed.InternalMeta[key] = Redacted
+			}
+		}
+		return scrubbed
+	})
+}
+
+// ScrubRegexp returns a Scrubber redacting the matches of re in the message,
+// internal error and string values of Meta and InternalMeta.
+func ScrubRegexp(re *regexp.Regexp) Scrubber {
+	return ScrubberFunc(func(e *Error) *Error {
+		return scrubMatches(e, func(s string) string {
+			return re.ReplaceAllString(s, Redacted)
+		})
+	})
+}
+
+var cardNumber = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+// ScrubPCI returns a Scrubber redacting card numbers (PAN) that pass the Luhn
+// check.
+func ScrubPCI() Scrubber {
+	return ScrubberFunc(func(e *Error) *Error {
+		return scrubMatches(e, func(s string) string {
+			return cardNumber.ReplaceAllStringFunc(s, func(match string) string {
+				if !luhn(match) {
+					return match
+				}
+				return Redacted
+			})
+		})
+	})
+}
+
+// scrubMatches returns a copy of e with replace applied to its message,
+// internal error and string meta values.
+func scrubMatches(e *Error, replace func(string) string) *Error {
+	scrubbed := e.copy()
+	scrubbed.Message = replace(e.Message)
+	scrubbed.UserMessage = replace(e.UserMessage)
+
+	if e.InternalError != nil {
+		if desc := replace(e.desc()); desc != e.desc() {
+			scrubbed.InternalError = errors.New(desc)
+		}
+	}
+
+	for _, meta := range []Meta{scrubbed.Meta, scrubbed.InternalMeta} {
+		for key, value := range meta {
+			if l, ok := value.(*lazyValue); ok {
+				value = l.value()
+			}
+			if s, ok := value.(string); ok {
+				meta[key] = replace(s)
+			}
+		}
+	}
+
+	return scrubbed
+}
+
+// luhn reports whether the digits in s pass the Luhn checksum.
+func luhn(s string) bool {
+	var sum, n int
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return sum%10 == 0
+}
+
+// copy returns a shallow copy of the error with its own Meta and
+// InternalMeta maps.
+func (e *Error) copy() *Error {
+	c := *e
+	c.pooled = false
+	c.metaOrder = append([]string(nil), e.metaOrder...)
+	if e.Meta != nil {
+		c.Meta = make(Meta, len(e.Meta))
+		for key, value := range e.Meta {
+			c.Meta[key] = value
+		}
+	}
+	if e.InternalMeta != nil {
+		c.InternalMeta = make(Meta, len(e.InternalMeta))
+		for key, value := range e.InternalMeta {
+			c.InternalMeta[key] = value
+		}
+	}
+	return &c
+}
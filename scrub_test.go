@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestScrubbers(t *testing.T) {
+	tests := []struct {
+		scrubber Scrubber
+		err      *Error
+		exp      *Error
+	}{
+		{
+			scrubber: ScrubKeys("password"),
+			err:      BadRequest("bad login", SetMeta(Meta{"user": "u1", "password": "1234"})),
+			exp:      BadRequest("bad login", SetMeta(Meta{"user": "u1", "password": Redacted})),
+		},
+		{
+			scrubber: ScrubRegexp(regexp.MustCompile(`rut=\S+`)),
+			err:      InvalidParams("invalid rut=12345678-9", SetMeta(Meta{"query": "rut=1-9", "n": 1})),
+			exp:      InvalidParams("invalid [REDACTED]", SetMeta(Meta{"query": Redacted, "n": 1})),
+		},
+		{
+			scrubber: ScrubPCI(),
+			err:      InternalServerFromError(errors.New("charge 4111 1111 1111 1111 failed"), "card 4111111111111111, ref 1234567890123"),
+			exp:      InternalServerFromError(errors.New("charge [REDACTED] failed"), "card [REDACTED], ref 1234567890123"),
+		},
+		{
+			scrubber: Scrubbers(ScrubKeys("a"), ScrubKeys("b")),
+			err:      BadRequest("", SetMeta(Meta{"a": 1, "b": 2, "c": 3})),
+			exp:      BadRequest("", SetMeta(Meta{"a": Redacted, "b": Redacted, "c": 3})),
+		},
+	}
+
+	for _, tt := range tests {
+		orig := tt.err.copy()
+		got := tt.scrubber.Scrub(tt.err)
+
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("Scrub(%v)\n exp: %v\n got: %v\n", tt.err, tt.exp, got)
+		}
+		if !reflect.DeepEqual(tt.err, orig) {
+			t.Errorf("Scrub(%v) modified the original error", orig)
+		}
+	}
+}
+
+func TestScrubbedEncoders(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	AddScrubber(ScrubKeys("password"))
+
+	err := BadRequest("bad login", SetMeta(Meta{"user": "u1", "password": "1234"}))
+
+	tests := []struct {
+		encoder string
+		encode  func(*Error) string
+	}{
+		{"MarshalJSON", func(e *Error) string { b, _ := json.Marshal(e); return string(b) }},
+		{"ToGRPC", func(e *Error) string { return FromGRPC(e.ToGRPC()).Meta["password"].(string) }},
+		{"ToConnect", func(e *Error) string { return FromConnect(e.ToConnect()).Meta["password"].(string) }},
+		{"ToSOAPFault", func(e *Error) string { b, _ := e.ToSOAPFault(SOAP12); return string(b) }},
+	}
+
+	for _, tt := range tests {
+		got := tt.encode(err)
+		if strings.Contains(got, "1234") || !strings.Contains(got, Redacted) {
+			t.Errorf("%s() with ScrubKeys\n exp: %s password\n got: %s\n", tt.encoder, Redacted, got)
+		}
+	}
+}
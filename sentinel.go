@@ -0,0 +1,37 @@
+package errors
+
+// sentinel is an error matching, through errors.Is, any *Error with the same
+// code.
+type sentinel Code
+
+func (s sentinel) Error() string {
+	return Code(s).String()
+}
+
+// Sentinel values for the built-in codes. errors.Is(err, ErrNotFound) reports
+// whether there is a not_found *Error anywhere in the chain of err.
+var (
+	ErrBadRequest          error = sentinel(StatusBadRequest)
+	ErrUnauthorized        error = sentinel(StatusUnauthorized)
+	ErrDelinquent          error = sentinel(StatusPaymentRequired)
+	ErrForbidden           error = sentinel(StatusForbidden)
+	ErrNotFound            error = sentinel(StatusNotFound)
+	ErrMethodNotAllowed    error = sentinel(StatusMethodNotAllowed)
+	ErrNotAcceptable       error = sentinel(StatusNotAcceptable)
+	ErrRequestTimeout      error = sentinel(StatusRequestTimeout)
+	ErrInvalidParams       error = sentinel(StatusUnprocessableEntity)
+	ErrRateLimit           error = sentinel(StatusTooManyRequests)
+	ErrClientClosedRequest error = sentinel(StatusClientClosedRequest)
+	ErrInternalServer      error = sentinel(StatusInternalServerError)
+)
+
+// Sentinel returns the sentinel value of the code, usable with errors.Is.
+func (c Code) Sentinel() error {
+	return sentinel(c)
+}
+
+// Is reports whether target is the sentinel of the error code.
+func (e *Error) Is(target error) bool {
+	s, ok := target.(sentinel)
+	return ok && Code(s) == e.StatusCode
+}
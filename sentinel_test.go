@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestSentinels(t *testing.T) {
+	tests := []struct {
+		err    error
+		target error
+		exp    bool
+	}{
+		{NotFound(""), ErrNotFound, true},
+		{NotFound(""), ErrBadRequest, false},
+		{fmt.Errorf("get account: %w", NotFound("")), ErrNotFound, true},
+		{InternalServerFromError(RateLimit(""), ""), ErrRateLimit, true},
+		{InternalServerFromError(RateLimit(""), ""), ErrInternalServer, true},
+		{pkgerrors.Wrap(Forbidden(""), "outer"), ErrForbidden, true},
+		{io.EOF, ErrInternalServer, false},
+		{New(418, ""), Code(418).Sentinel(), true},
+		{NotFoundFromError(io.EOF, ""), io.EOF, true},
+	}
+
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, tt.target); got != tt.exp {
+			t.Errorf("errors.Is(%v, %v)\n exp: %v\n got: %v\n", tt.err, tt.target, tt.exp, got)
+		}
+	}
+}
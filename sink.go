@@ -0,0 +1,38 @@
+package errors
+
+// Sink receives errors reported by the package, e.g. an error tracker or a
+// log shipper. Alerting sinks route them by their PriorityOf.
+type Sink interface {
+	Report(e *Error)
+	Flush()
+}
+
+// AddSink registers s to receive reported errors.
+func AddSink(s Sink) {
+	UpdateConfig(func(c *Config) { c.Sinks = append(c.Sinks, s) })
+}
+
+// Report sends e to every registered sink, after applying the registered
+// scrubbers, and counts its occurrence, see Occurrences.
+func Report(e *Error) {
+	if e == nil {
+		return
+	}
+	e = scrub(e)
+	if e.pooled {
+		// the caller may Release it while the sinks still hold it
+		e = e.copy()
+	}
+	count(e)
+
+	for _, s := range e.cfg().Sinks {
+		s.Report(e)
+	}
+}
+
+// Flush flushes every registered sink.
+func Flush() {
+	for _, s := range loadConfig().Sinks {
+		s.Flush()
+	}
+}
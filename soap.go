@@ -0,0 +1,193 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// SOAPVersion is the version of the SOAP faults, see ToSOAPFault.
+type SOAPVersion int
+
+// SOAP versions
+const (
+	SOAP11 SOAPVersion = iota
+	SOAP12
+)
+
+const (
+	soap11NS = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12NS = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+type soap11Fault struct {
+	XMLName xml.Name   `xml:"soap:Fault"`
+	NS      string     `xml:"xmlns:soap,attr"`
+	Code    string     `xml:"faultcode"`
+	String  string     `xml:"faultstring"`
+	Detail  soapDetail `xml:"detail"`
+}
+
+type soap12Fault struct {
+	XMLName xml.Name `xml:"env:Fault"`
+	NS      string   `xml:"xmlns:env,attr"`
+	Code    struct {
+		Value   string `xml:"env:Value"`
+		Subcode struct {
+			Value string `xml:"env:Value"`
+		} `xml:"env:Subcode"`
+	} `xml:"env:Code"`
+	Reason struct {
+		Text struct {
+			Lang  string `xml:"xml:lang,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"env:Text"`
+	} `xml:"env:Reason"`
+	Detail soapDetail `xml:"env:Detail"`
+}
+
+// soapFault is a fault of any SOAP version, as decoded by FromSOAPFault.
+type soapFault struct {
+	Code   string `xml:"faultcode"`
+	String string `xml:"faultstring"`
+	Code12 struct {
+		Value string `xml:"Value"`
+	} `xml:"Code"`
+	Reason struct {
+		Text []string `xml:"Text"`
+	} `xml:"Reason"`
+	Detail   soapDetail `xml:"detail"`
+	Detail12 soapDetail `xml:"Detail"`
+}
+
+type soapDetail struct {
+	Error *soapError `xml:"error"`
+}
+
+type soapError struct {
+	StatusCode Code       `xml:"status_code,attr"`
+	ErrorID    string     `xml:"error_id,attr"`
+	Meta       []soapMeta `xml:"meta"`
+}
+
+// soapMeta is a Meta entry, holding strings as is and other values in json.
+type soapMeta struct {
+	Name  string `xml:"name,attr"`
+	JSON  bool   `xml:"json,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// ToSOAPFault encodes the error into a SOAP Fault element of the given
+// version, to be written into the Body of the envelope. The faultcode is
+// Client (Sender) for errors of the bad_request class and Server (Receiver)
+// for the rest, and the detail carries StatusCode and Meta so
+// FromSOAPFault can restore them. The registered scrubbers are applied first.
+func (e *Error) ToSOAPFault(version SOAPVersion) ([]byte, error) {
+	e = scrub(e)
+	detail := soapDetail{&soapError{StatusCode: e.StatusCode, ErrorID: e.ErrorID()}}
+	for _, key := range e.MetaKeys() {
+		entry := soapMeta{Name: key}
+		if s, ok := metaValue(e.Meta[key]).(string); ok {
+			entry.Value = s
+		} else {
+			buff, err := json.Marshal(metaValue(e.Meta[key]))
+			if err != nil {
+				continue
+			}
+			entry.JSON = true
+			entry.Value = string(buff)
+		}
+		detail.Error.Meta = append(detail.Error.Meta, entry)
+	}
+
+	client := e.StatusCode.httpStatus() < 500
+
+	var fault interface{}
+	if version == SOAP12 {
+		f := soap12Fault{NS: soap12NS, Detail: detail}
+		f.Code.Value = "env:Receiver"
+		if client {
+			f.Code.Value = "env:Sender"
+		}
+		f.Code.Subcode.Value = e.ErrorID()
+		f.Reason.Text.Lang = "en"
+		f.Reason.Text.Value = e.Message
+		fault = f
+	} else {
+		f := soap11Fault{NS: soap11NS, Code: "soap:Server", String: e.Message, Detail: detail}
+		if client {
+			f.Code = "soap:Client"
+		}
+		fault = f
+	}
+
+	return xml.Marshal(fault)
+}
+
+// FromSOAPFault returns a new Error from a SOAP 1.1 or 1.2 Fault, or an
+// envelope holding one. If the fault was encoded with ToSOAPFault then
+// StatusCode and Meta are restored, otherwise the faultcode is mapped to a
+// bad_request or internal_server error.
+func FromSOAPFault(b []byte) *Error {
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return InternalServerFromError(fmt.Errorf("errors: no soap fault: %v", err), UnexpectedMsg)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "Fault" {
+			continue
+		}
+
+		var f soapFault
+		if err := dec.DecodeElement(&f, &start); err != nil {
+			return InternalServerFromError(err, UnexpectedMsg)
+		}
+		return f.error()
+	}
+}
+
+// error returns the Error of the fault.
+func (f *soapFault) error() *Error {
+	code, msg := f.Code, f.String
+	if len(code) == 0 {
+		code = f.Code12.Value
+		if len(f.Reason.Text) > 0 {
+			msg = f.Reason.Text[0]
+		}
+	}
+
+	// e.g. soap:Client.InvalidAccount
+	code = code[strings.Index(code, ":")+1:]
+	if i := strings.Index(code, "."); i >= 0 {
+		code = code[:i]
+	}
+
+	e := &Error{StatusCode: StatusInternalServerError, Message: msg}
+	switch code {
+	case "Client", "Sender":
+		e.StatusCode = StatusBadRequest
+	}
+
+	detail := f.Detail.Error
+	if detail == nil {
+		detail = f.Detail12.Error
+	}
+	if detail == nil || detail.StatusCode == 0 {
+		return e
+	}
+
+	e.StatusCode = detail.StatusCode
+	for _, entry := range detail.Meta {
+		var value interface{} = entry.Value
+		if entry.JSON && json.Unmarshal([]byte(entry.Value), &value) != nil {
+			continue
+		}
+		SetMetaNoCopy(Meta{entry.Name: value})(e)
+	}
+	return e
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestToSOAPFaultFromSOAPFault(t *testing.T) {
+	tests := []struct {
+		err     *Error
+		version SOAPVersion
+		exp     string
+	}{
+		{BadRequest("let's go", SetMeta(Meta{"hi": "ho"})), SOAP11, `<faultcode>soap:Client</faultcode><faultstring>let&#39;s go</faultstring>`},
+		{NotFound("no account", SetMeta(Meta{"account": "a1", "attempts": float64(3)})), SOAP12, `<env:Code><env:Value>env:Sender</env:Value><env:Subcode><env:Value>not_found</env:Value></env:Subcode></env:Code>`},
+		{InternalServer("db down"), SOAP11, `<faultcode>soap:Server</faultcode>`},
+		{RateLimit(""), SOAP12, `<env:Detail><error status_code="429" error_id="rate_limit"></error></env:Detail>`},
+	}
+
+	for _, tt := range tests {
+		fault, err := tt.err.ToSOAPFault(tt.version)
+		if err != nil {
+			t.Fatalf("(%v).ToSOAPFault(%d) unexpected error: %v", tt.err, tt.version, err)
+		}
+		if !strings.Contains(string(fault), tt.exp) {
+			t.Errorf("(%v).ToSOAPFault(%d)\n exp: %s\n got: %s\n", tt.err, tt.version, tt.exp, fault)
+		}
+
+		if got := FromSOAPFault(fault); !reflect.DeepEqual(got, tt.err) {
+			t.Errorf("FromSOAPFault(%s)\n exp: %v\n got: %v\n", fault, tt.err, got)
+		}
+	}
+}
+
+func TestFromSOAPFault(t *testing.T) {
+	tests := []struct {
+		fault string
+		exp   *Error
+	}{
+		{
+			`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><soapenv:Fault>` +
+				`<faultcode>soapenv:Client.InvalidAccount</faultcode><faultstring>invalid account</faultstring></soapenv:Fault></soapenv:Body></soapenv:Envelope>`,
+			New(StatusBadRequest, "invalid account"),
+		},
+		{
+			`<env:Fault xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Code><env:Value>env:Receiver</env:Value></env:Code>` +
+				`<env:Reason><env:Text xml:lang="en">core banking down</env:Text></env:Reason></env:Fault>`,
+			New(StatusInternalServerError, "core banking down"),
+		},
+	}
+
+	for _, tt := range tests {
+		if got := FromSOAPFault([]byte(tt.fault)); !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("FromSOAPFault(%s)\n exp: %v\n got: %v\n", tt.fault, tt.exp, got)
+		}
+	}
+
+	if got := FromSOAPFault([]byte(`<html></html>`)); got.StatusCode != StatusInternalServerError {
+		t.Errorf("FromSOAPFault(html)\n exp: %d\n got: %d\n", StatusInternalServerError, got.StatusCode)
+	}
+}
+
+func TestToSOAPFaultScrubbed(t *testing.T) {
+	defer SetConfig(CurrentConfig())
+	AddScrubber(ScrubKeys("card"))
+
+	fault, err := BadRequest("invalid card", SetMeta(Meta{"card": "4111111111111111"})).ToSOAPFault(SOAP11)
+	if err != nil || strings.Contains(string(fault), "4111") || !strings.Contains(string(fault), Redacted) {
+		t.Errorf("ToSOAPFault() with ScrubKeys\n exp: %s card\n got: %s (%v)\n", Redacted, fault, err)
+	}
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// maxStackDepth limits the number of frames captured.
+const maxStackDepth = 32
+
+// CaptureStacks enables or disables capturing the stack of the errors created
+// by this package constructors. Disabled by default.
+func CaptureStacks(enabled bool) {
+	UpdateConfig(func(c *Config) { c.CaptureStacks = enabled })
+}
+
+// SampleStacks makes the constructors capture the stack of only the given
+// fraction of the errors, between 0 and 1, when CaptureStacks is enabled.
+// The stack of the first error of every fingerprint is always captured, so
+// each failure mode keeps one. Zero, the default, disables sampling.
+//
+//	errors.CaptureStacks(true)
+//	errors.SampleStacks(0.01)
+func SampleStacks(rate float64) {
+	UpdateConfig(func(c *Config) { c.StackSampling = rate })
+}
+
+// random returns a pseudo-random number in [0, 1), overridable by tests.
+var random = rand.Float64
+
+var stackFingerprints = struct {
+	sync.Mutex
+	seen map[string]bool
+}{
+	seen: map[string]bool{},
+}
+
+// sampleStack reports whether the stack of the error must be captured in
+// the given configuration.
+func (e *Error) sampleStack(cfg *Config) bool {
+	if !cfg.CaptureStacks {
+		return false
+	}
+	if cfg.StackSampling <= 0 || cfg.StackSampling >= 1 {
+		return true
+	}
+
+	fingerprint := e.Fingerprint()
+
+	stackFingerprints.Lock()
+	first := !stackFingerprints.seen[fingerprint] && len(stackFingerprints.seen) < maxFingerprints
+	if first {
+		stackFingerprints.seen[fingerprint] = true
+	}
+	stackFingerprints.Unlock()
+
+	return first || random() < cfg.StackSampling
+}
+
+// callers returns the program counters of the stack, skipping the given
+// number of frames, 0 being the caller of callers.
+func callers(skip int) []uintptr {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	return pcs[:n]
+}
+
+// StackTrace returns the stack captured on construction in the
+// github.com/pkg/errors format, nil if it was not captured.
+func (e *Error) StackTrace() pkgerrors.StackTrace {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := make(pkgerrors.StackTrace, len(e.stack))
+	for i, pc := range e.stack {
+		frames[i] = pkgerrors.Frame(pc)
+	}
+	return frames
+}
+
+// stackEntries returns the captured stack as "function file:line" entries,
+// or the lines of the stack stored in InternalMeta by Recover.
+func (e *Error) stackEntries() []string {
+	if len(e.stack) == 0 {
+		if s, ok := e.InternalMeta[StackKey].(string); ok && len(s) > 0 {
+			return strings.Split(strings.TrimSpace(s), "\n")
+		}
+		return nil
+	}
+
+	var entries []string
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		entries = append(entries, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		if !more {
+			return entries
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestStackTrace(t *testing.T) {
+	if st := New(StatusNotFound, "").StackTrace(); st != nil {
+		t.Errorf("StackTrace() without capture\n exp: nil\n got: %v\n", st)
+	}
+
+	CaptureStacks(true)
+	defer CaptureStacks(false)
+
+	tests := []struct {
+		err *Error
+	}{
+		{New(StatusNotFound, "")},
+		{NotFound("")},
+		{InternalServerFromError(nil, "")},
+	}
+
+	for _, tt := range tests {
+		st := tt.err.StackTrace()
+		if len(st) == 0 {
+			t.Fatalf("StackTrace() of %v is empty", tt.err)
+		}
+
+		if got := fmt.Sprintf("%n", st[0]); got != "TestStackTrace" {
+			t.Errorf("StackTrace()[0] of %v\n exp: %s\n got: %s\n", tt.err, "TestStackTrace", got)
+		}
+	}
+}
+
+func TestSampleStacks(t *testing.T) {
+	CaptureStacks(true)
+	SampleStacks(0.5)
+	defer func() {
+		CaptureStacks(false)
+		SampleStacks(0)
+	}()
+
+	draws := []float64{0.7, 0.2}
+	random = func() float64 {
+		r := draws[0]
+		draws = draws[1:]
+		return r
+	}
+	defer func() {
+		random = rand.Float64
+	}()
+
+	tests := []struct {
+		msg string
+		exp bool
+	}{
+		{"first occurrence", true},
+		{"first occurrence", false},
+		{"first occurrence", true},
+		{"another occurrence", true},
+	}
+
+	for _, tt := range tests {
+		if got := len(InternalServer(tt.msg).StackTrace()) > 0; got != tt.exp {
+			t.Errorf("StackTrace() of sampled %q\n exp: %t\n got: %t\n", tt.msg, tt.exp, got)
+		}
+	}
+}
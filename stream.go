@@ -0,0 +1,82 @@
+package errors
+
+import (
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// StreamErrorKey is the trailer metadata key carrying the error set by
+// SetStreamError.
+const StreamErrorKey = "x-error-bin"
+
+// SetStreamError sets err, encoded like ToGRPC does, into the trailer
+// metadata of a server stream. Unlike returning it from the handler, the
+// stream still ends with an OK status, so clients can tell a failed item
+// from an aborted stream. Errors that are not an *Error are set as
+// internal_server errors.
+//
+//	if err := process(item); err != nil {
+//		errors.SetStreamError(stream, err)
+//		return nil
+//	}
+func SetStreamError(stream grpc.ServerStream, err error) error {
+	e := BuildError(err)
+	if e == nil {
+		return nil
+	}
+
+	buff, err := proto.Marshal(e.GRPCStatus().Proto())
+	if err != nil {
+		return err
+	}
+
+	stream.SetTrailer(metadata.Pairs(StreamErrorKey, string(buff)))
+	return nil
+}
+
+// FromStreamTrailer returns the error set by SetStreamError into the given
+// trailer metadata, nil if there is none.
+//
+//	for {
+//		item, err := stream.Recv()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+//	if e := errors.FromStreamTrailer(stream.Trailer()); e != nil {
+//		...
+//	}
+func FromStreamTrailer(md metadata.MD) *Error {
+	values := md.Get(StreamErrorKey)
+	if len(values) == 0 {
+		return nil
+	}
+
+	var s spb.Status
+	if err := proto.Unmarshal([]byte(values[0]), &s); err != nil {
+		return InternalServerFromError(err, UnexpectedMsg)
+	}
+	return FromGRPC(status.FromProto(&s).Err())
+}
+
+// StreamFrame returns the error encoded like ToGRPC does as an Any message,
+// to be sent as the final message of streams whose messages have an Any
+// field for errors.
+func (e *Error) StreamFrame() (*anypb.Any, error) {
+	return anypb.New(e.GRPCStatus().Proto())
+}
+
+// FromStreamFrame returns the error encoded by StreamFrame, nil if frame
+// does not hold one.
+func FromStreamFrame(frame *anypb.Any) *Error {
+	var s spb.Status
+	if frame == nil || frame.UnmarshalTo(&s) != nil {
+		return nil
+	}
+	return FromGRPC(status.FromProto(&s).Err())
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	trailer metadata.MD
+}
+
+func (s *testServerStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func (s *testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamError(t *testing.T) {
+	err := NotFound("no item", SetMeta(Meta{"item": "i1"}))
+
+	stream := &testServerStream{}
+	if err := SetStreamError(stream, err); err != nil {
+		t.Fatalf("SetStreamError() unexpected error: %v", err)
+	}
+	if got := FromStreamTrailer(stream.trailer); !got.Equal(err) {
+		t.Errorf("FromStreamTrailer()\n%s", Diff(err, got))
+	}
+	if got := FromStreamTrailer(metadata.MD{}); got != nil {
+		t.Errorf("FromStreamTrailer(empty)\n exp: nil\n got: %v\n", got)
+	}
+
+	frame, ferr := err.StreamFrame()
+	if ferr != nil {
+		t.Fatalf("StreamFrame() unexpected error: %v", ferr)
+	}
+	if got := FromStreamFrame(frame); !got.Equal(err) {
+		t.Errorf("FromStreamFrame()\n%s", Diff(err, got))
+	}
+	if got := FromStreamFrame(nil); got != nil {
+		t.Errorf("FromStreamFrame(nil)\n exp: nil\n got: %v\n", got)
+	}
+}
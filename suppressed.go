@@ -0,0 +1,69 @@
+package errors
+
+// Combine returns a copy of primary, keeping its code and message, with
+// secondary attached as a suppressed error, serialized alongside it in the
+// suppressed array. It is meant for operations that failed and then failed
+// to clean up, e.g. a rollback. If either error is nil the other one is
+// returned.
+//
+//	if err := tx.Rollback(); err != nil {
+//		return errors.Combine(e, errors.InternalServerFromError(err, "rollback failed"))
+//	}
+func Combine(primary, secondary *Error) *Error {
+	if primary == nil {
+		return secondary
+	}
+	if secondary == nil {
+		return primary
+	}
+
+	c := primary.copy()
+	AddSuppressed(secondary)(c)
+	return c
+}
+
+// AddSuppressed adds errs to the suppressed errors of the error: follow-up
+// failures that are retained and serialized, unlike the cause chain they do
+// not change the root cause, errors.Is or errors.As. Nil errors are ignored.
+//
+//	errors.InternalServerFromError(err, "transfer failed", errors.AddSuppressed(notifyErr))
+func AddSuppressed(errs ...error) errorParamsSetter {
+	return func(e *Error) {
+		for _, err := range errs {
+			if err != nil {
+				e.suppressed = append(e.suppressed[:len(e.suppressed):len(e.suppressed)], err)
+			}
+		}
+	}
+}
+
+// Suppressed returns the suppressed errors, see AddSuppressed and Combine.
+// Decoded errors hold them as *Error.
+func (e *Error) Suppressed() []error {
+	return append([]error(nil), e.suppressed...)
+}
+
+// suppressedErrors returns the suppressed errors to serialize, converted
+// with BuildError.
+func (e *Error) suppressedErrors() []*Error {
+	if len(e.suppressed) == 0 {
+		return nil
+	}
+
+	errs := make([]*Error, 0, len(e.suppressed))
+	for _, err := range e.suppressed {
+		if s := BuildError(err); s != nil {
+			errs = append(errs, s)
+		}
+	}
+	return errs
+}
+
+// setSuppressed sets the decoded suppressed errors.
+func (e *Error) setSuppressed(errs []*Error) {
+	for _, s := range errs {
+		if s != nil {
+			e.suppressed = append(e.suppressed, s)
+		}
+	}
+}
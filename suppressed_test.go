@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	primary := NotFound("no account")
+	secondary := InternalServer("rollback failed")
+
+	e := Combine(primary, secondary)
+	if e.StatusCode != StatusNotFound || e.Message != "no account" {
+		t.Errorf("Combine()\n exp: %v\n got: %v\n", primary, e)
+	}
+	if len(primary.suppressed) != 0 {
+		t.Errorf("Combine() modified the primary error: %v", primary.suppressed)
+	}
+	if got := Combine(nil, secondary); got != secondary {
+		t.Errorf("Combine(nil, secondary)\n exp: %v\n got: %v\n", secondary, got)
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+	var decoded Error
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if d := Diff(e, &decoded); d != "" {
+		t.Errorf("Unmarshal(Marshal(Combine()))\n%s", d)
+	}
+
+	if d := Diff(e, FromGRPC(e.ToGRPC())); d != "" {
+		t.Errorf("FromGRPC(ToGRPC(Combine()))\n%s", d)
+	}
+	if d := Diff(primary, e); d == "" {
+		t.Errorf("Diff(primary, Combine()) expected a suppressed difference")
+	}
+}
+
+func TestAddSuppressed(t *testing.T) {
+	notify := stderrors.New("notification failed")
+	e := InternalServer("transfer failed", AddSuppressed(notify, nil, NotFound("no webhook")))
+
+	got := e.Suppressed()
+	if len(got) != 2 || got[0] != notify {
+		t.Fatalf("Suppressed()\n exp: [%v no webhook]\n got: %v\n", notify, got)
+	}
+	if stderrors.Is(e, notify) || RootCause(e) != e {
+		t.Errorf("AddSuppressed() changed the cause chain of %v", e)
+	}
+
+	decoded := FromGRPC(e.ToGRPC()).Suppressed()
+	if len(decoded) != 2 || CodeOf(decoded[0]) != StatusInternalServerError || CodeOf(decoded[1]) != StatusNotFound {
+		t.Errorf("FromGRPC(ToGRPC()).Suppressed()\n exp: [internal_server not_found]\n got: %v\n", decoded)
+	}
+}
@@ -0,0 +1,172 @@
+package errors
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"toml": toml.Unmarshal,
+}
+
+// LoadTranslations registers the translations of every go-i18n message file
+// (JSON or TOML) found in fsys. The language is taken from the file name,
+// e.g. "es.toml" or "active.es-CL.json", and message ids are error_ids.
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	errors.LoadTranslations(locales)
+func LoadTranslations(fsys fs.FS) error {
+	set, err := parseTranslations(fsys)
+	if err != nil {
+		return err
+	}
+
+	translations.Lock()
+	defer translations.Unlock()
+
+	for key, t := range set {
+		setTranslation(key.tag, key.id, t)
+	}
+	return nil
+}
+
+// translationKey identifies the translation of an error_id in a language.
+type translationKey struct {
+	tag language.Tag
+	id  string
+}
+
+// parseTranslations returns the translations of the message files in fsys,
+// see LoadTranslations.
+func parseTranslations(fsys fs.FS) (map[translationKey]translation, error) {
+	set := map[translationKey]translation{}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (path.Ext(name) != ".json" && path.Ext(name) != ".toml") {
+			return nil
+		}
+
+		buf, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		file, err := i18n.ParseMessageFileBytes(buf, name, unmarshalFuncs)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range file.Messages {
+			forms := map[plural.Form]string{
+				plural.Zero:  msg.Zero,
+				plural.One:   msg.One,
+				plural.Two:   msg.Two,
+				plural.Few:   msg.Few,
+				plural.Many:  msg.Many,
+				plural.Other: msg.Other,
+			}
+			t, err := newTranslation(file.Tag, msg.ID, forms)
+			if err != nil {
+				return err
+			}
+			set[translationKey{file.Tag, msg.ID}] = t
+		}
+		return nil
+	})
+	return set, err
+}
+
+// WatchTranslations loads the translations in dir and reloads them every time
+// a file changes, checking every interval until ctx is done. A reload
+// replaces the translations loaded from dir, so the messages removed from
+// its files are unregistered too. Intended for development only.
+func WatchTranslations(ctx context.Context, dir string, interval time.Duration) error {
+	fsys := os.DirFS(dir)
+
+	last, err := lastModified(fsys)
+	if err != nil {
+		return err
+	}
+	loaded, err := reloadTranslations(fsys, nil)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			modified, err := lastModified(fsys)
+			if err != nil || !modified.After(last) {
+				continue
+			}
+			if set, err := reloadTranslations(fsys, loaded); err == nil {
+				loaded, last = set, modified
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reloadTranslations registers the translations of fsys in place of the
+// previously loaded ones, and returns them.
+func reloadTranslations(fsys fs.FS, loaded map[translationKey]translation) (map[translationKey]translation, error) {
+	set, err := parseTranslations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	translations.Lock()
+	defer translations.Unlock()
+
+	for key := range loaded {
+		if _, ok := set[key]; !ok {
+			deleteTranslation(key.tag, key.id)
+		}
+	}
+	for key, t := range set {
+		setTranslation(key.tag, key.id, t)
+	}
+	return set, nil
+}
+
+// lastModified returns the most recent modification time of the files in
+// fsys.
+func lastModified(fsys fs.FS) (time.Time, error) {
+	var last time.Time
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+		return nil
+	})
+	return last, err
+}
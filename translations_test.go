@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestLoadTranslations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/es.toml":        {Data: []byte("bad_request = \"Solicitud inválida\"\n")},
+		"locales/active.pt.json": {Data: []byte(`{"bad_request": {"other": "Pedido inválido"}}`)},
+		"locales/README.md":      {Data: []byte("ignored")},
+	}
+
+	if err := LoadTranslations(fsys); err != nil {
+		t.Fatalf("LoadTranslations() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		tag language.Tag
+		exp string
+	}{
+		{language.Spanish, "Solicitud inválida"},
+		{language.Portuguese, "Pedido inválido"},
+	}
+
+	for _, tt := range tests {
+		got := BadRequest("").Localize(tt.tag).UserMessage
+		if got != tt.exp {
+			t.Errorf("Localize(%v)\n exp: %q\n got: %q\n", tt.tag, tt.exp, got)
+		}
+	}
+}
+
+func TestWatchTranslations(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "it.toml")
+	write := func(data string, modified time.Time) {
+		if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+	registered := func(id string) bool {
+		translations.RLock()
+		defer translations.RUnlock()
+		_, ok := translations.msgs[language.Italian][id]
+		return ok
+	}
+
+	write("watched_a = \"A\"\nwatched_b = \"B\"\n", time.Now().Add(-time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := WatchTranslations(ctx, dir, 10*time.Millisecond); err != nil {
+		t.Fatalf("WatchTranslations() unexpected error: %v", err)
+	}
+	if !registered("watched_a") || !registered("watched_b") {
+		t.Fatal("WatchTranslations() did not load the translations")
+	}
+
+	write("watched_a = \"A\"\n", time.Now())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for registered("watched_b") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if registered("watched_b") {
+		t.Error("WatchTranslations() kept a translation removed from the files")
+	}
+	if !registered("watched_a") {
+		t.Error("WatchTranslations() removed a translation still in the files")
+	}
+}
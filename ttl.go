@@ -0,0 +1,31 @@
+package errors
+
+import "time"
+
+// SetTTL sets the time the error may be cached for, e.g. to cache a
+// not_found result of a lookup alongside the successful ones. The TTL is
+// kept in memory only, it is not serialized.
+//
+//	e := errors.NotFound("no account", errors.SetTTL(30*time.Second))
+//	cache.Set(id, e)
+//	...
+//	if e, ok := cached.(*errors.Error); ok && !e.Expired() {
+//		return e
+//	}
+func SetTTL(d time.Duration) errorParamsSetter {
+	return func(e *Error) {
+		e.expiresAt = now().Add(d)
+	}
+}
+
+// ExpiresAt returns the time the error stops being cacheable, zero if it has
+// no TTL.
+func (e *Error) ExpiresAt() time.Time {
+	return e.expiresAt
+}
+
+// Expired reports whether the TTL of the error elapsed. Errors without TTL
+// never expire.
+func (e *Error) Expired() bool {
+	return !e.expiresAt.IsZero() && !now().Before(e.expiresAt)
+}
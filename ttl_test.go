@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTL(t *testing.T) {
+	current := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() {
+		now = time.Now
+	}()
+
+	e := NotFound("no account", SetTTL(30*time.Second))
+
+	tests := []struct {
+		elapsed time.Duration
+		exp     bool
+	}{
+		{0, false},
+		{29 * time.Second, false},
+		{30 * time.Second, true},
+		{time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		current = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC).Add(tt.elapsed)
+		if got := e.Expired(); got != tt.exp {
+			t.Errorf("Expired() after %v\n exp: %v\n got: %v\n", tt.elapsed, tt.exp, got)
+		}
+	}
+
+	if NotFound("no account").Expired() {
+		t.Errorf("Expired() without TTL\n exp: false\n got: true\n")
+	}
+	if got := BuildError(e); got.ExpiresAt() != e.ExpiresAt() {
+		t.Errorf("BuildError().ExpiresAt()\n exp: %v\n got: %v\n", e.ExpiresAt(), got.ExpiresAt())
+	}
+}
@@ -1,14 +1,72 @@
 package errors
 
-// BuildError ...
+import (
+	"errors"
+	"strings"
+)
+
+// AnnotationsKey is the InternalMeta key holding the annotations of the
+// errors wrapping an *Error found by BuildError.
+const AnnotationsKey = "annotations"
+
+// BuildError returns err if it is an *Error. If err wraps an *Error, e.g.
+// with fmt.Errorf("%w") or github.com/pkg/errors, a copy of it is returned
+// with the annotations of the wrapping errors in its InternalMeta. E values
+// are converted with Err. Otherwise it returns the conversion of the first
+// registered converter recognizing err, or an internal_server error with err
+// as internal error.
 func BuildError(err error) *Error {
 	if err == nil {
 		return nil
 	}
 
-	if err, ok := (err).(*Error); ok {
-		return err
+	var e *Error
+	// errors.As would walk a cyclic chain forever
+	if cyclic(err) || !errors.As(err, &e) {
+		for cur, i := err, 0; cur != nil && e == nil && i < maxChainDepth; cur, i = unwrap(cur), i+1 {
+			e, _ = cur.(*Error)
+		}
+	}
+
+	if e != nil {
+		if e == err {
+			return e
+		}
+
+		var annotations []string
+		cur := err
+		for i := 0; cur != nil && cur != error(e) && i < maxChainDepth; cur, i = unwrap(cur), i+1 {
+			if a := annotation(cur); len(a) > 0 {
+				annotations = append(annotations, a)
+			}
+		}
+
+		// annotations are only recorded for linear chains, not for trees
+		// such as errors.Join
+		annotated := e.copy()
+		if cur != nil && len(annotations) > 0 {
+			SetInternalMeta(Meta{AnnotationsKey: annotations})(annotated)
+		}
+		return annotated
+	}
+
+	if v, ok := err.(E); ok {
+		return v.Err()
+	}
+
+	if e, ok := convert(err); ok {
+		return e
 	}
 
 	return InternalServerFromError(err, "unexpected error")
 }
+
+// annotation returns the description err adds to the error it wraps, e.g.
+// "get account" for fmt.Errorf("get account: %w", err).
+func annotation(err error) string {
+	desc := err.Error()
+	if next := unwrap(err); next != nil {
+		desc = strings.TrimSuffix(strings.TrimSuffix(desc, next.Error()), ": ")
+	}
+	return desc
+}
@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestBuildError(t *testing.T) {
+	var (
+		errTest  = errors.New("testing: test error")
+		notFound = NotFound("no account")
+	)
+
+	tests := []struct {
+		err error
+		exp *Error
+	}{
+		{nil, nil},
+		{notFound, notFound},
+		{errTest, InternalServerFromError(errTest, "unexpected error")},
+		{pkgerrors.Wrap(notFound, "get account"), NotFound("no account", SetInternalMeta(Meta{AnnotationsKey: []string{"get account"}}))},
+		{pkgerrors.WithMessage(pkgerrors.Wrap(notFound, "get account"), "handler"), NotFound("no account", SetInternalMeta(Meta{AnnotationsKey: []string{"handler", "get account"}}))},
+		{fmt.Errorf("handler: %w", fmt.Errorf("get account: %w", notFound)), NotFound("no account", SetInternalMeta(Meta{AnnotationsKey: []string{"handler", "get account"}}))},
+		{errors.Join(io.EOF, fmt.Errorf("get account: %w", notFound)), NotFound("no account")},
+	}
+
+	for _, tt := range tests {
+		if got := BuildError(tt.err); !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("BuildError(%v)\n exp: %v\n got: %v\n", tt.err, tt.exp, got)
+		}
+	}
+
+	wrapped := pkgerrors.Wrap(InternalServerFromError(errTest, ""), "outer")
+	if got := pkgerrors.Cause(wrapped); got != errTest {
+		t.Errorf("pkgerrors.Cause(%v)\n exp: %v\n got: %v\n", wrapped, errTest, got)
+	}
+	if got := RootCause(wrapped); got != errTest {
+		t.Errorf("RootCause(%v)\n exp: %v\n got: %v\n", wrapped, errTest, got)
+	}
+}
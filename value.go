@@ -0,0 +1,42 @@
+package errors
+
+import "fmt"
+
+// E is a lightweight error value made of a code and a static message. It
+// can be created, compared and returned without allocations, e.g. when
+// validating every row of a file, and converted to a full *Error with Err
+// when needed.
+//
+//	var errInvalidAmount = errors.E{Code: errors.StatusUnprocessableEntity, Message: "invalid amount"}
+type E struct {
+	Code    Code
+	Message string
+}
+
+// Err returns the *Error of the value, nil if the value is zero.
+func (e E) Err(setters ...errorParamsSetter) *Error {
+	if e.IsZero() {
+		return nil
+	}
+	return newError(e.Code, nil, e.Message, setters)
+}
+
+// IsZero reports whether e is the zero value, meaning no error.
+func (e E) IsZero() bool {
+	return e == E{}
+}
+
+// Error returns the same representation of the *Error of the value.
+func (e E) Error() string {
+	str := fmt.Sprintf("status_code=%d error_id=%q", e.Code, (&Error{StatusCode: e.Code}).ErrorID())
+	if len(e.Message) > 0 {
+		str += fmt.Sprintf(" msg=%q", e.Message)
+	}
+	return str
+}
+
+// Is reports whether target is the sentinel of the value code.
+func (e E) Is(target error) bool {
+	s, ok := target.(sentinel)
+	return ok && Code(s) == e.Code
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+)
+
+var errInvalidAmount = E{StatusUnprocessableEntity, "invalid amount"}
+
+func validateAmount(amount int) E {
+	if amount <= 0 {
+		return errInvalidAmount
+	}
+	return E{}
+}
+
+func TestE(t *testing.T) {
+	if v := validateAmount(10); !v.IsZero() || v.Err() != nil {
+		t.Errorf("validateAmount(10)\n exp: zero value\n got: %v\n", v)
+	}
+
+	v := validateAmount(0)
+	exp := InvalidParams("invalid amount")
+
+	if got := v.Err(); !got.Equal(exp) {
+		t.Errorf("(%v).Err()\n%s", v, Diff(exp, got))
+	}
+	if got := BuildError(v); !got.Equal(exp) {
+		t.Errorf("BuildError(%v)\n%s", v, Diff(exp, got))
+	}
+	if v.Error() != exp.Error() {
+		t.Errorf("(%v).Error()\n exp: %q\n got: %q\n", v, exp.Error(), v.Error())
+	}
+	if !errors.Is(v, ErrInvalidParams) {
+		t.Errorf("errors.Is(%v, ErrInvalidParams) = false", v)
+	}
+}
+
+func BenchmarkValidateE(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if v := validateAmount(i % 2); !v.IsZero() && v.Code != StatusUnprocessableEntity {
+			b.Fatal(v)
+		}
+	}
+}
+
+func BenchmarkValidateError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err *Error
+		if i%2 == 0 {
+			err = InvalidParams("invalid amount", SetMeta(Meta{"row": i}))
+		}
+		if err != nil && err.StatusCode != StatusUnprocessableEntity {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryableKey is the Meta key holding whether a webhook delivery may be
+// retried, as decided by the receiver, see WebhookRetry.
+const RetryableKey = "retryable"
+
+// WebhookError is the error envelope of the webhook deliveries, returned by
+// the receivers of the webhooks in the body of failed responses:
+//
+//	{"error":{"code":429,"error_id":"rate_limit","msg":"slow down","retryable":true,"retry_after":"30s"}}
+type WebhookError struct {
+	Code       Code   `json:"code"`
+	ID         string `json:"error_id"`
+	Message    string `json:"msg,omitempty"`
+	Retryable  bool   `json:"retryable"`
+	RetryAfter string `json:"retry_after,omitempty"` // duration string, e.g. "30s"
+	Meta       Meta   `json:"meta,omitempty"`
+}
+
+// Webhook returns the webhook error envelope of the error. It is retryable
+// if the Meta says so under RetryableKey or its code is, and the retry hint
+// is the one set with SetRetryAfter.
+func (e *Error) Webhook() WebhookError {
+	w := WebhookError{
+		Code:    e.StatusCode,
+		ID:      e.ErrorID(),
+		Message: e.Message,
+		Meta:    e.Meta,
+	}
+	w.Retryable, _ = WebhookRetry(e)
+	if d, ok := e.RetryAfter(); ok {
+		w.RetryAfter = d.String()
+	}
+	return w
+}
+
+// MarshalWebhook returns the webhook error envelope of the error. The
+// registered scrubbers are applied first.
+func (e *Error) MarshalWebhook() ([]byte, error) {
+	return json.Marshal(struct {
+		Error WebhookError `json:"error"`
+	}{scrub(e).Webhook()})
+}
+
+// WriteWebhook is like WriteHTTP but writes the webhook error envelope, with
+// the Retry-After header for errors with a retry hint, for the endpoints
+// receiving webhooks from partners.
+func WriteWebhook(w http.ResponseWriter, err error) {
+	e := BuildError(err)
+	if e == nil {
+		return
+	}
+	logError(e)
+	e = e.sanitized()
+
+	b, _ := e.MarshalWebhook() // there is no much more to do in case of failure
+	if d, ok := e.RetryAfter(); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int((d+time.Second-1)/time.Second)))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(e.StatusCode.httpStatus())
+	w.Write(b)
+}
+
+// FromWebhookResponse returns the Error of a failed webhook delivery, nil if
+// the response is successful. Responses with a webhook error envelope are
+// decoded, keeping its retryable flag and retry hint in Meta, others are
+// retryable for the 408, 429 and 5xx status codes, with the retry hint of the
+// Retry-After header. The body is consumed and closed.
+func FromWebhookResponse(resp *http.Response) *Error {
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return InternalServerFromError(err, UnexpectedMsg, SetMetaNoCopy(Meta{RetryableKey: true}))
+	}
+
+	var envelope struct {
+		Error *WebhookError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil && envelope.Error.Code != 0 {
+		w := envelope.Error
+		meta := Meta{RetryableKey: w.Retryable}
+		if len(w.RetryAfter) > 0 {
+			meta[RetryAfterKey] = w.RetryAfter
+		}
+		return New(w.Code, w.Message, SetMeta(w.Meta), SetMetaNoCopy(meta))
+	}
+
+	status := resp.StatusCode
+	meta := Meta{RetryableKey: status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		meta[RetryAfterKey] = (time.Duration(seconds) * time.Second).String()
+	}
+	return New(Code(status), http.StatusText(status), SetMetaNoCopy(meta))
+}
+
+// WebhookRetry reports whether the webhook delivery that failed with err may
+// be retried, as decided by the receiver under RetryableKey or by the code
+// of err, and the time to wait before retrying, zero if there is no hint.
+//
+//	if retry, after := errors.WebhookRetry(err); retry {
+//		dispatcher.Schedule(delivery, after)
+//	}
+func WebhookRetry(err error) (bool, time.Duration) {
+	if err == nil {
+		return false, 0
+	}
+
+	e := BuildError(err)
+	after, _ := e.RetryAfter()
+	return retryable(e), after
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalWebhook(t *testing.T) {
+	tests := []struct {
+		err *Error
+		exp string
+	}{
+		{RateLimit("slow down", SetRetryAfter(30*time.Second)), `{"error":{"code":429,"error_id":"rate_limit","msg":"slow down","retryable":true,"retry_after":"30s","meta":{"retry_after":"30s"}}}`},
+		{BadRequest("invalid signature"), `{"error":{"code":400,"error_id":"bad_request","msg":"invalid signature","retryable":false}}`},
+		{BadRequest("try again", SetMeta(Meta{RetryableKey: true})), `{"error":{"code":400,"error_id":"bad_request","msg":"try again","retryable":true,"meta":{"retryable":true}}}`},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.err.MarshalWebhook()
+		if err != nil {
+			t.Fatalf("MarshalWebhook() unexpected error: %v", err)
+		}
+		if string(got) != tt.exp {
+			t.Errorf("(%v).MarshalWebhook()\n exp: %s\n got: %s\n", tt.err, tt.exp, got)
+		}
+	}
+}
+
+func TestFromWebhookResponse(t *testing.T) {
+	tests := []struct {
+		status int
+		header http.Header
+		body   string
+		retry  bool
+		after  time.Duration
+		code   Code
+	}{
+		{http.StatusOK, nil, ``, false, 0, 0},
+		{http.StatusBadRequest, nil, `{"error":{"code":400,"error_id":"bad_request","msg":"unknown event","retryable":false}}`, false, 0, StatusBadRequest},
+		{http.StatusConflict, nil, `{"error":{"code":409,"error_id":"conflict","retryable":true,"retry_after":"1m0s"}}`, true, time.Minute, 409},
+		{http.StatusServiceUnavailable, http.Header{"Retry-After": {"120"}}, `<html></html>`, true, 2 * time.Minute, 503},
+		{http.StatusNotFound, nil, `not found`, false, 0, StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Header: tt.header, Body: ioutil.NopCloser(strings.NewReader(tt.body))}
+		got := FromWebhookResponse(resp)
+		if tt.code == 0 {
+			if got != nil {
+				t.Errorf("FromWebhookResponse(%d)\n exp: nil\n got: %v\n", tt.status, got)
+			}
+			continue
+		}
+
+		if got.StatusCode != tt.code {
+			t.Errorf("FromWebhookResponse(%d) code\n exp: %d\n got: %d\n", tt.status, tt.code, got.StatusCode)
+		}
+		if retry, after := WebhookRetry(got); retry != tt.retry || after != tt.after {
+			t.Errorf("WebhookRetry(%v)\n exp: %t %v\n got: %t %v\n", got, tt.retry, tt.after, retry, after)
+		}
+	}
+}
+
+func TestWriteWebhook(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteWebhook(rec, RateLimit("slow down", SetRetryAfter(1500*time.Millisecond)))
+
+	resp := rec.Result()
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("WriteWebhook() Retry-After\n exp: 2\n got: %s\n", got)
+	}
+	if retry, after := WebhookRetry(FromWebhookResponse(resp)); !retry || after != 1500*time.Millisecond {
+		t.Errorf("WebhookRetry(WriteWebhook())\n exp: true 1.5s\n got: %t %v\n", retry, after)
+	}
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// WireFormat is the encoding of errors sent through grpc.
+type WireFormat int32
+
+// Wire formats. FromGRPC decodes both.
+const (
+	// WireV1 encodes the error as json in the status message.
+	WireV1 WireFormat = iota
+	// WireV2 keeps the plain message as the status message and encodes the
+	// error as a status detail.
+	WireV2
+)
+
+// WireFormatKey is the incoming metadata key a client may set to "v1" or
+// "v2" to choose the wire format of the errors of its requests.
+const WireFormatKey = "x-errors-wire-format"
+
+// v2DetailKey is the field of the status detail holding a WireV2 error.
+const v2DetailKey = "error"
+
+// SetWireFormat sets the default wire format, WireV1 by default.
+func SetWireFormat(f WireFormat) {
+	UpdateConfig(func(c *Config) { c.WireFormat = f })
+}
+
+type wireFormatKey struct{}
+
+// WithWireFormat returns a copy of ctx choosing the wire format of the
+// errors encoded with ToGRPCContext, e.g. from an interceptor enabling
+// WireV2 per connection or service.
+func WithWireFormat(ctx context.Context, f WireFormat) context.Context {
+	return context.WithValue(ctx, wireFormatKey{}, f)
+}
+
+// wireFormatOf returns the wire format chosen by ctx, its incoming metadata
+// or the given default one, in that order.
+func wireFormatOf(ctx context.Context, def WireFormat) WireFormat {
+	if f, ok := ctx.Value(wireFormatKey{}).(WireFormat); ok {
+		return f
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(WireFormatKey); len(values) > 0 {
+			switch values[0] {
+			case "v1":
+				return WireV1
+			case "v2":
+				return WireV2
+			}
+		}
+	}
+	return def
+}
+
+// ToGRPCContext is like ToGRPC but uses the wire format chosen by ctx, see
+// WithWireFormat and WireFormatKey, and the configuration of ctx, see
+// WithConfig.
+func (e *Error) ToGRPCContext(ctx context.Context) error {
+	e = e.withConfig(ctx)
+	s, _ := e.grpcStatus(wireFormatOf(ctx, e.cfg().WireFormat))
+	return s.Err()
+}
+
+// v2Status returns the WireV2 grpc status of the error encoded as the given
+// json object.
+func (e *Error) v2Status(buff []byte) *status.Status {
+	s := status.New(e.StatusCode.grpcCode(e.cfg()), e.Message)
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buff, &fields); err != nil {
+		return s
+	}
+
+	detail, err := structpb.NewStruct(map[string]interface{}{v2DetailKey: fields})
+	if err != nil {
+		return s
+	}
+	return withDetails(s, detail)
+}
+
+// v2Payload returns the json object of a WireV2 error carried in the
+// details of s.
+func v2Payload(s *status.Status) (string, bool) {
+	for _, detail := range s.Details() {
+		st, ok := detail.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		if v, ok := st.Fields[v2DetailKey]; ok && v.GetStructValue() != nil {
+			buff, err := v.GetStructValue().MarshalJSON()
+			if err != nil {
+				return "", false
+			}
+			return string(buff), true
+		}
+	}
+	return "", false
+}
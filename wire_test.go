@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestWireFormat(t *testing.T) {
+	defer SetWireFormat(WireV1)
+
+	e := NotFound("no account", SetOrderedMeta("account", "a1", "bank", "b1"), AllowFallback())
+	v2 := WithWireFormat(context.Background(), WireV2)
+	v2md := metadata.NewIncomingContext(context.Background(), metadata.Pairs(WireFormatKey, "v2"))
+	v1md := metadata.NewIncomingContext(context.Background(), metadata.Pairs(WireFormatKey, "v1"))
+
+	tests := []struct {
+		def WireFormat
+		ctx context.Context
+		msg string
+	}{
+		{WireV1, context.Background(), `{"meta":{"account":"a1","bank":"b1"},"msg":"no account","meta_order":["account","bank"],"fallback_allowed":true}`},
+		{WireV1, v2, "no account"},
+		{WireV1, v2md, "no account"},
+		{WireV2, context.Background(), "no account"},
+		{WireV2, v1md, `{"meta":{"account":"a1","bank":"b1"},"msg":"no account","meta_order":["account","bank"],"fallback_allowed":true}`},
+	}
+
+	for _, tt := range tests {
+		SetWireFormat(tt.def)
+
+		err := e.ToGRPCContext(tt.ctx)
+		if got := status.Convert(err).Message(); got != tt.msg {
+			t.Errorf("ToGRPCContext() message\n exp: %s\n got: %s\n", tt.msg, got)
+		}
+		if got := FromGRPC(err); !got.Equal(e) || got.MetaKeys()[0] != "account" {
+			t.Errorf("FromGRPC(ToGRPCContext())\n%s", Diff(e, got))
+		}
+	}
+}
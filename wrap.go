@@ -0,0 +1,156 @@
+package errors
+
+import (
+	"errors"
+	"reflect"
+)
+
+// maxChainDepth limits the number of errors of a cause chain walked and
+// serialized by this package.
+const maxChainDepth = 100
+
+// cycleDesc replaces the description of an internal error whose cause chain
+// loops, e.g. an error that ends up wrapping itself through BuildError, as
+// describing it would never end.
+const cycleDesc = "[cyclic error chain]"
+
+// Unwrap returns the InternalError, so the standard errors.Is and errors.As
+// functions can inspect the cause chain. The constructors break cause chains
+// that loop, see breakCycle, but a loop made by assigning InternalError
+// afterwards is only detected by the functions of this package.
+func (e *Error) Unwrap() error {
+	return e.InternalError
+}
+
+// Cause returns the InternalError, for compatibility with
+// github.com/pkg/errors.
+func (e *Error) Cause() error {
+	return e.InternalError
+}
+
+// breakCycle replaces the InternalError by a cycleDesc error if the cause
+// chain of e loops, e.g. an error that ends up wrapping itself through
+// BuildError in a setter. It is called once by the constructors, so Unwrap
+// does not have to check the chain on every call.
+func (e *Error) breakCycle() {
+	if e.InternalError != nil && cyclic(e) {
+		e.InternalError = errors.New(cycleDesc)
+	}
+}
+
+// RootCause returns the deepest error of the cause chain of err, or the
+// last one walked if the chain is deeper than the package limit.
+func RootCause(err error) error {
+	for i := 1; i < maxChainDepth; i++ {
+		next := unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return err
+}
+
+// desc returns the description of the internal error.
+func (e *Error) desc() string {
+	if e.InternalError == nil {
+		return ""
+	}
+	return e.InternalError.Error()
+}
+
+// cyclic reports whether the cause chain starting at err loops. Chains
+// longer than ten times maxChainDepth are considered cyclic too.
+func cyclic(err error) bool {
+	slow, fast := err, err
+	for i := 0; i < 10*maxChainDepth; i++ {
+		if fast = unwrap(fast); fast == nil {
+			return false
+		}
+		if fast = unwrap(fast); fast == nil {
+			return false
+		}
+		slow = unwrap(slow)
+		if same(slow, fast) {
+			return true
+		}
+	}
+	return true
+}
+
+// same reports whether a and b are the same error, without panicking on
+// errors of non comparable types.
+func same(a, b error) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
+
+// unwrap returns the next error of the cause chain, following both the
+// standard Unwrap and the github.com/pkg/errors Cause methods.
+func unwrap(err error) error {
+	if next := errors.Unwrap(err); next != nil {
+		return next
+	}
+	if c, ok := err.(interface{ Cause() error }); ok {
+		return c.Cause()
+	}
+	return nil
+}
+
+// wireCause is the serialized form of an error of the cause chain.
+type wireCause struct {
+	Message    string `json:"msg,omitempty"`
+	StatusCode Code   `json:"status_code,omitempty"`
+	Meta       Meta   `json:"meta,omitempty"`
+}
+
+// cause is a synthetic error rebuilt from a serialized cause chain.
+type cause struct {
+	msg  string
+	next error
+}
+
+func (c *cause) Error() string { return c.msg }
+func (c *cause) Unwrap() error { return c.next }
+
+// encodeCauses serializes the cause chain starting at err, outermost first,
+// up to maxChainDepth causes.
+func encodeCauses(err error) []wireCause {
+	var causes []wireCause
+	loops := cyclic(err)
+	for ; err != nil && len(causes) < maxChainDepth; err = unwrap(err) {
+		if e, ok := err.(*Error); ok {
+			causes = append(causes, wireCause{Message: e.Message, StatusCode: e.StatusCode, Meta: e.Meta})
+			continue
+		}
+		if loops {
+			causes = append(causes, wireCause{Message: cycleDesc})
+			break
+		}
+		causes = append(causes, wireCause{Message: err.Error()})
+	}
+	return causes
+}
+
+// decodeCauses rebuilds a cause chain serialized by encodeCauses. Causes that
+// were an *Error are rebuilt as such, the rest as synthetic errors with the
+// same description. Causes beyond maxChainDepth are dropped.
+func decodeCauses(causes []wireCause) error {
+	if len(causes) > maxChainDepth {
+		causes = causes[:maxChainDepth]
+	}
+
+	var next error
+	for i := len(causes) - 1; i >= 0; i-- {
+		c := causes[i]
+		switch {
+		case c.StatusCode != 0:
+			next = &Error{StatusCode: c.StatusCode, Message: intern(c.Message), Meta: c.Meta, InternalError: next}
+		case next == nil:
+			next = errors.New(c.Message)
+		default:
+			next = &cause{msg: c.Message, next: next}
+		}
+	}
+	return next
+}
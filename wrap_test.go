@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFromGRPCCauseChain(t *testing.T) {
+	SetMode(Debug)
+	defer SetMode(Production)
+
+	inner := InvalidParamsFromError(io.EOF, "bad body", SetMeta(Meta{"field": "amount"}))
+	err := InternalServerFromError(fmt.Errorf("decode: %w", inner), "")
+
+	got := FromGRPC(err.ToGRPC())
+
+	var e *Error
+	if !errors.As(got.InternalError, &e) || !e.Equal(inner, IgnoreInternalError()) {
+		t.Errorf("FromGRPC() cause chain\n exp: %v\n got: %v\n", inner, e)
+	}
+
+	chain := []string{}
+	for cur := got.InternalError; cur != nil; cur = errors.Unwrap(cur) {
+		chain = append(chain, cur.Error())
+	}
+
+	exp := []string{
+		err.InternalError.Error(),
+		inner.Error(),
+		"EOF",
+	}
+	if fmt.Sprint(chain) != fmt.Sprint(exp) {
+		t.Errorf("FromGRPC() cause chain\n exp: %q\n got: %q\n", exp, chain)
+	}
+
+	if root := RootCause(got); root.Error() != io.EOF.Error() {
+		t.Errorf("RootCause(%v)\n exp: %v\n got: %v\n", got, io.EOF, root)
+	}
+}
+
+func TestCyclicChain(t *testing.T) {
+	// a setter making the error wrap itself, broken by the constructor
+	e := NotFound("no account", func(e *Error) { e.InternalError = fmt.Errorf("get account: %w", e) })
+
+	if got := e.Error(); !strings.Contains(got, cycleDesc) {
+		t.Errorf("Error() of cyclic chain\n exp: %q\n got: %q\n", cycleDesc, got)
+	}
+	if got := e.Unwrap(); got == nil || got.Error() != cycleDesc {
+		t.Errorf("Unwrap() of cyclic chain\n exp: %s\n got: %v\n", cycleDesc, got)
+	}
+	if !errors.Is(e, e) || errors.Is(e, ErrInternalServer) {
+		t.Errorf("errors.Is() of cyclic chain\n exp: only itself\n")
+	}
+	if got := e.With(func(e *Error) { e.InternalError = e }); got.desc() != cycleDesc {
+		t.Errorf("With() of cyclic chain\n exp: %q\n got: %q\n", cycleDesc, got.desc())
+	}
+
+	// a loop made after construction, walked by the package functions
+	e = NotFound("no account")
+	e.InternalError = fmt.Errorf("get account: %w", e)
+
+	if got := BuildError(e.InternalError); got.StatusCode != StatusNotFound {
+		t.Errorf("BuildError() of cyclic chain\n exp: %d\n got: %d\n", StatusNotFound, got.StatusCode)
+	}
+	if got := RootCause(e); got == nil {
+		t.Errorf("RootCause() of cyclic chain\n exp: an error of the chain\n got: <nil>\n")
+	}
+	if got := fmt.Sprintf("%+v", e); !strings.Contains(got, cycleDesc) {
+		t.Errorf("Sprintf(%%+v) of cyclic chain\n exp: %q\n got: %q\n", cycleDesc, got)
+	}
+
+	SetMode(Debug)
+	defer SetMode(Production)
+	if got := FromGRPC(e.ToGRPC()); got.StatusCode != StatusNotFound {
+		t.Errorf("FromGRPC(ToGRPC()) of cyclic chain\n exp: %d\n got: %d\n", StatusNotFound, got.StatusCode)
+	}
+}
+
+func TestDeepChain(t *testing.T) {
+	var err error = errors.New("root")
+	for i := 0; i < 3*maxChainDepth; i++ {
+		err = fmt.Errorf("layer %d: %w", i, err)
+	}
+
+	if got := len(encodeCauses(err)); got != maxChainDepth {
+		t.Errorf("encodeCauses() of deep chain\n exp: %d\n got: %d\n", maxChainDepth, got)
+	}
+	if cyclic(err) {
+		t.Errorf("cyclic() of deep chain\n exp: false\n got: true\n")
+	}
+	if got := CodeOf(InternalServerFromError(err, "")); got != StatusInternalServerError {
+		t.Errorf("CodeOf() of deep chain\n exp: %d\n got: %d\n", StatusInternalServerError, got)
+	}
+}